@@ -0,0 +1,100 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+import (
+	"math"
+
+	"github.com/humilityai/sam"
+)
+
+// Accumulator keeps running column means and variances of
+// rows that are pushed to it one at a time, so that the
+// statistics of a stream of rows can be found without
+// storing the rows. It uses Welford's algorithm.
+type Accumulator struct {
+	count   int
+	columns int
+	means   sam.SliceFloat64
+	m2      sam.SliceFloat64
+}
+
+// NewAccumulator creates an Accumulator for rows with
+// the specified column count.
+func NewAccumulator(columns int) *Accumulator {
+	return &Accumulator{
+		columns: columns,
+		means:   make(sam.SliceFloat64, columns),
+		m2:      make(sam.SliceFloat64, columns),
+	}
+}
+
+// Count will return the number of rows that have been pushed.
+func (a *Accumulator) Count() int {
+	return a.count
+}
+
+// Means will return the average value of each column of the
+// rows that have been pushed. Every mean is NaN if no rows
+// have been pushed.
+func (a *Accumulator) Means() sam.SliceFloat64 {
+	means := make(sam.SliceFloat64, a.columns)
+	if a.count == 0 {
+		for j := range means {
+			means[j] = math.NaN()
+		}
+		return means
+	}
+
+	copy(means, a.means)
+
+	return means
+}
+
+// Push will add the row to the running statistics.
+// If the size of the row does not match the number of columns
+// of the accumulator then an ErrRowSize will be returned.
+func (a *Accumulator) Push(row sam.SliceFloat64) error {
+	if len(row) != a.columns {
+		return ErrRowSize
+	}
+
+	a.count++
+	n := float64(a.count)
+	for j, v := range row {
+		d := v - a.means[j]
+		a.means[j] += d / n
+		a.m2[j] += d * (v - a.means[j])
+	}
+
+	return nil
+}
+
+// Variances will return the sample variance of each column of
+// the rows that have been pushed. Every variance is NaN if fewer
+// than two rows have been pushed.
+func (a *Accumulator) Variances() sam.SliceFloat64 {
+	variances := make(sam.SliceFloat64, a.columns)
+	n := float64(a.count - 1)
+	for j := range variances {
+		if a.count < 2 {
+			variances[j] = math.NaN()
+			continue
+		}
+		variances[j] = a.m2[j] / n
+	}
+
+	return variances
+}
@@ -0,0 +1,71 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/humilityai/sam"
+)
+
+func TestAccumulator(t *testing.T) {
+	matrix := NewMatrixFloat64(3)
+	matrix.AddRows([][]float64{
+		{1, 10, -4},
+		{2, 30, 8},
+		{3, 20, 1e6},
+		{4, 50, 1e6 + 1},
+		{5, 40, -2.5},
+	})
+
+	accumulator := NewAccumulator(3)
+	iter := matrix.Iterator()
+	for iter.Next() {
+		row, _ := iter.Current()
+		err := accumulator.Push(row)
+		if err != nil {
+			t.Errorf("push error: %+v", err)
+		}
+	}
+
+	if accumulator.Count() != 5 {
+		t.Errorf("count %d is not 5", accumulator.Count())
+	}
+
+	means, variances := matrix.ColumnMeans(), matrix.ColumnVariances()
+	for j := 0; j < 3; j++ {
+		if math.Abs(accumulator.Means()[j]-means[j]) > 1e-9*math.Abs(means[j]) {
+			t.Errorf("accumulated means %v are not %v", accumulator.Means(), means)
+		}
+		if math.Abs(accumulator.Variances()[j]-variances[j]) > 1e-9*variances[j] {
+			t.Errorf("accumulated variances %v are not %v", accumulator.Variances(), variances)
+		}
+	}
+
+	if means[0] != 3 || variances[0] != 2.5 {
+		t.Errorf("mean %v and variance %v of the first column are not 3 and 2.5", means[0], variances[0])
+	}
+
+	err := accumulator.Push(sam.SliceFloat64{1})
+	if err != ErrRowSize {
+		t.Errorf("ErrRowSize was not returned for a short row")
+	}
+
+	empty := NewAccumulator(2)
+	if !math.IsNaN(empty.Means()[0]) || !math.IsNaN(empty.Variances()[0]) {
+		t.Errorf("statistics of an empty accumulator are not NaN")
+	}
+}
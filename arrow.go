@@ -0,0 +1,78 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/humilityai/sam"
+)
+
+// FromArrowRecord creates a Matrix from an Apache Arrow record,
+// with one matrix column for each column of the record.
+// Null values are read as NaN.
+// If any column of the record is not a Float64 column then an
+// ErrInvalidFormat will be returned.
+func FromArrowRecord(record array.Record) (*MatrixFloat64, error) {
+	columns := int(record.NumCols())
+	rows := int(record.NumRows())
+
+	data := make(sam.SliceFloat64, rows*columns)
+	for j, col := range record.Columns() {
+		values, ok := col.(*array.Float64)
+		if !ok {
+			return nil, ErrInvalidFormat
+		}
+
+		for i, v := range values.Float64Values() {
+			if values.IsNull(i) {
+				v = math.NaN()
+			}
+			data[i*columns+j] = v
+		}
+	}
+
+	return &MatrixFloat64{
+		data:    data,
+		columns: columns,
+	}, nil
+}
+
+// ToArrowRecord will create and return a new Apache Arrow record
+// from the MatrixFloat64. Each column of the matrix becomes a Float64
+// column of the record, named by its column index.
+// The record must be released by the caller once it is no longer used.
+func (m *MatrixFloat64) ToArrowRecord() array.Record {
+	fields := make([]arrow.Field, m.columns)
+	for j := range fields {
+		fields[j] = arrow.Field{
+			Name: strconv.Itoa(j),
+			Type: arrow.PrimitiveTypes.Float64,
+		}
+	}
+
+	builder := array.NewRecordBuilder(memory.NewGoAllocator(), arrow.NewSchema(fields, nil))
+	defer builder.Release()
+
+	for j := 0; j < m.columns; j++ {
+		builder.Field(j).(*array.Float64Builder).AppendValues(m.column(j), nil)
+	}
+
+	return builder.NewRecord()
+}
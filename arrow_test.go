@@ -0,0 +1,62 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestMatrixFloat64Arrow(t *testing.T) {
+	matrix := NewMatrixFloat64(3)
+	matrix.AddRows([][]float64{{1, 2, 3}, {4, 5, 6}, {-7, 8.5, 0}, {10, 11, 12}})
+
+	record := matrix.ToArrowRecord()
+	defer record.Release()
+
+	if record.NumRows() != 4 || record.NumCols() != 3 {
+		t.Errorf("record dimensions (%d, %d) are not (4, 3)", record.NumRows(), record.NumCols())
+	}
+
+	column := record.Column(1).(*array.Float64)
+	if column.Value(2) != 8.5 {
+		t.Errorf("record value at (2, 1) is %v and not 8.5", column.Value(2))
+	}
+
+	decoded, err := FromArrowRecord(record)
+	if err != nil {
+		t.Errorf("from arrow record error: %+v", err)
+	}
+
+	if !decoded.Equal(matrix) {
+		t.Errorf("decoded matrix %v is not %v", decoded.data, matrix.data)
+	}
+
+	// non-float64 columns are rejected
+	schema := arrow.NewSchema([]arrow.Field{{Name: "0", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	builder := array.NewRecordBuilder(memory.NewGoAllocator(), schema)
+	defer builder.Release()
+	builder.Field(0).(*array.Int64Builder).AppendValues([]int64{1, 2}, nil)
+	ints := builder.NewRecord()
+	defer ints.Release()
+
+	_, err = FromArrowRecord(ints)
+	if err != ErrInvalidFormat {
+		t.Errorf("ErrInvalidFormat was not returned for an int64 column")
+	}
+}
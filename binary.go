@@ -0,0 +1,184 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+
+	"github.com/humilityai/sam"
+)
+
+const (
+	binaryMagic   uint32 = 0x584d4648 // "HFMX"
+	binaryVersion byte   = 1
+
+	// binaryHeaderSize is the size of the header in bytes. The
+	// header is padded so that the values which follow it are
+	// 8-byte aligned.
+	binaryHeaderSize = 24
+
+	// maxValues is the largest number of float64 values whose
+	// size in bytes can be held in an int.
+	maxValues = int64(^uint(0)>>1) / 8
+
+	// readChunkSize is the largest number of values that
+	// readFloat64s reads at one time.
+	readChunkSize = 4096
+)
+
+// WriteBinary will write the matrix to the writer in a compact
+// little-endian binary layout:
+//
+//	magic number (uint32)
+//	version (byte) followed by 3 bytes of padding
+//	columns (int64)
+//	rows (int64)
+//	values (float64), in row-major order
+//
+// The matrix can be read back with ReadBinary.
+func (m *MatrixFloat64) WriteBinary(w io.Writer) error {
+	_, err := w.Write(m.binaryHeader(binaryMagic))
+	if err != nil {
+		return err
+	}
+
+	buf := make([]byte, 8*m.columns)
+	for i := 0; i < len(m.data); i += m.columns {
+		for j, v := range m.data[i : i+m.columns] {
+			binary.LittleEndian.PutUint64(buf[j*8:], math.Float64bits(v))
+		}
+
+		_, err = w.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ReadBinary will read a matrix that was written by WriteBinary.
+// If the data does not begin with the expected magic number and
+// version then an ErrInvalidFormat will be returned, and if the
+// data ends before every value has been read then an ErrTruncated
+// will be returned.
+// Memory is only allocated for values as they are read, so a header
+// with corrupt dimensions cannot cause a large allocation.
+func ReadBinary(r io.Reader) (*MatrixFloat64, error) {
+	columns, rows, err := readBinaryHeader(r, binaryMagic)
+	if err != nil {
+		return nil, err
+	}
+
+	matrix := NewMatrixFloat64(columns)
+	matrix.data, err = readFloat64s(r, matrix.data, int64(rows)*int64(columns))
+	if err != nil {
+		return nil, err
+	}
+
+	return matrix, nil
+}
+
+// binaryHeader returns the header that describes the matrix
+// in the layout identified by the magic number.
+func (m *MatrixFloat64) binaryHeader(magic uint32) []byte {
+	header := make([]byte, binaryHeaderSize)
+	binary.LittleEndian.PutUint32(header[0:], magic)
+	header[4] = binaryVersion
+	binary.LittleEndian.PutUint64(header[8:], uint64(m.columns))
+	binary.LittleEndian.PutUint64(header[16:], uint64(m.Rows()))
+
+	return header
+}
+
+func readBinaryHeader(r io.Reader, magic uint32) (columns, rows int, err error) {
+	header := make([]byte, binaryHeaderSize)
+	err = readFull(r, header)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return parseBinaryHeader(header, magic)
+}
+
+// parseBinaryHeader returns the dimensions described by the header.
+// Dimensions whose values could not be held in memory are rejected
+// with an ErrInvalidFormat. Readers must not size anything from the
+// dimensions alone since they are not known to be genuine until the
+// values have been read.
+func parseBinaryHeader(header []byte, magic uint32) (columns, rows int, err error) {
+	if binary.LittleEndian.Uint32(header[0:]) != magic || header[4] != binaryVersion {
+		return 0, 0, ErrInvalidFormat
+	}
+
+	c := int64(binary.LittleEndian.Uint64(header[8:]))
+	n := int64(binary.LittleEndian.Uint64(header[16:]))
+	if c < 0 || n < 0 || c > maxValues {
+		return 0, 0, ErrInvalidFormat
+	}
+
+	// only a matrix without rows can have no columns
+	if c == 0 {
+		if n != 0 {
+			return 0, 0, ErrInvalidFormat
+		}
+	} else if n > maxValues/c {
+		return 0, 0, ErrInvalidFormat
+	}
+
+	return int(c), int(n), nil
+}
+
+// readFull fills the buffer from the reader, returning
+// ErrTruncated if the reader ends first.
+func readFull(r io.Reader, buf []byte) error {
+	_, err := io.ReadFull(r, buf)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return ErrTruncated
+	}
+
+	return err
+}
+
+// readFloat64s reads n little-endian float64 values from the reader
+// and appends them to the data. The values are read in chunks so that
+// the data only grows as values actually arrive.
+func readFloat64s(r io.Reader, data sam.SliceFloat64, n int64) (sam.SliceFloat64, error) {
+	chunk := int64(readChunkSize)
+	if n < chunk {
+		chunk = n
+	}
+
+	buf := make([]byte, 8*chunk)
+	for n > 0 {
+		if n < chunk {
+			buf = buf[:8*n]
+		}
+
+		err := readFull(r, buf)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := 0; i < len(buf); i += 8 {
+			data = append(data, math.Float64frombits(binary.LittleEndian.Uint64(buf[i:])))
+		}
+		n -= int64(len(buf) / 8)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,140 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/rand"
+	"testing"
+)
+
+func TestMatrixFloat64Binary(t *testing.T) {
+	matrix := NewMatrixFloat64(3)
+	matrix.AddRows([][]float64{{1, 2, 3}, {-4.5, 0, 1e300}})
+
+	var buf bytes.Buffer
+	err := matrix.WriteBinary(&buf)
+	if err != nil {
+		t.Errorf("write binary error: %+v", err)
+	}
+
+	if buf.Len() != binaryHeaderSize+6*8 {
+		t.Errorf("encoded size %d is not %d", buf.Len(), binaryHeaderSize+6*8)
+	}
+	encoded := buf.Bytes()
+
+	decoded, err := ReadBinary(bytes.NewReader(encoded))
+	if err != nil {
+		t.Errorf("read binary error: %+v", err)
+	}
+
+	if !decoded.Equal(matrix) {
+		t.Errorf("decoded matrix %v is not %v", decoded.data, matrix.data)
+	}
+
+	// truncated values
+	_, err = ReadBinary(bytes.NewReader(encoded[:len(encoded)-3]))
+	if err != ErrTruncated {
+		t.Errorf("ErrTruncated was not returned for truncated values: %v", err)
+	}
+
+	// truncated header
+	_, err = ReadBinary(bytes.NewReader(encoded[:10]))
+	if err != ErrTruncated {
+		t.Errorf("ErrTruncated was not returned for a truncated header: %v", err)
+	}
+
+	// bad magic number
+	corrupt := append([]byte{}, encoded...)
+	corrupt[0] ^= 0xff
+	_, err = ReadBinary(bytes.NewReader(corrupt))
+	if err != ErrInvalidFormat {
+		t.Errorf("ErrInvalidFormat was not returned for a bad magic number: %v", err)
+	}
+
+	// unsupported version
+	corrupt = append([]byte{}, encoded...)
+	corrupt[4] = binaryVersion + 1
+	_, err = ReadBinary(bytes.NewReader(corrupt))
+	if err != ErrInvalidFormat {
+		t.Errorf("ErrInvalidFormat was not returned for an unsupported version: %v", err)
+	}
+
+	// column and row counts too large to allocate
+	for _, dims := range [][2]uint64{{1 << 61, 1}, {1 << 40, 1 << 40}, {1 << 63, 0}} {
+		corrupt = append([]byte{}, encoded[:binaryHeaderSize]...)
+		binary.LittleEndian.PutUint64(corrupt[8:], dims[0])
+		binary.LittleEndian.PutUint64(corrupt[16:], dims[1])
+		_, err = ReadBinary(bytes.NewReader(corrupt))
+		if err != ErrInvalidFormat {
+			t.Errorf("ErrInvalidFormat was not returned for %d columns and %d rows: %v", dims[0], dims[1], err)
+		}
+	}
+
+	// large column counts that can be held are not allocated
+	// until their values have been read
+	for _, columns := range []uint64{1 << 50, uint64(maxValues)} {
+		corrupt = append([]byte{}, encoded[:binaryHeaderSize]...)
+		binary.LittleEndian.PutUint64(corrupt[8:], columns)
+		binary.LittleEndian.PutUint64(corrupt[16:], 0)
+		empty, err := ReadBinary(bytes.NewReader(corrupt))
+		if err != nil || empty.Rows() != 0 {
+			t.Errorf("matrix with %d columns and no rows was not read: %v", columns, err)
+		}
+
+		binary.LittleEndian.PutUint64(corrupt[16:], 1)
+		_, err = ReadBinary(bytes.NewReader(corrupt))
+		if err != ErrTruncated {
+			t.Errorf("ErrTruncated was not returned for a row of %d columns: %v", columns, err)
+		}
+	}
+
+	// values that span more than one chunk
+	large := Random(3, readChunkSize+1, rand.New(rand.NewSource(1)))
+	var largeBuf bytes.Buffer
+	large.WriteBinary(&largeBuf)
+	decoded, err = ReadBinary(&largeBuf)
+	if err != nil || !decoded.Equal(large) {
+		t.Errorf("matrix spanning several chunks was not read: %v", err)
+	}
+}
+
+func TestMatrixFloat64BinaryNoColumns(t *testing.T) {
+	matrix, _ := FromRows(nil)
+
+	var buf bytes.Buffer
+	err := matrix.WriteBinary(&buf)
+	if err != nil {
+		t.Errorf("write binary error: %+v", err)
+	}
+
+	decoded, err := ReadBinary(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Errorf("read binary error: %+v", err)
+	}
+
+	if r, c := decoded.Dimensions(); r != 0 || c != 0 {
+		t.Errorf("dimensions %dx%d are not 0x0", r, c)
+	}
+
+	// rows cannot be held without columns
+	corrupt := buf.Bytes()
+	binary.LittleEndian.PutUint64(corrupt[16:], 1)
+	_, err = ReadBinary(bytes.NewReader(corrupt))
+	if err != ErrInvalidFormat {
+		t.Errorf("ErrInvalidFormat was not returned for rows without columns: %v", err)
+	}
+}
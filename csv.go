@@ -0,0 +1,53 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// ReadCSVString will read CSV records from the reader into a new
+// MatrixString with the specified column count. The cells are kept
+// exactly as they were decoded and no numeric parsing is done.
+// If a record does not have the specified number of fields then an
+// ErrRowSize will be returned.
+func ReadCSVString(r io.Reader, columns int) (*MatrixString, error) {
+	if columns <= 0 {
+		return nil, ErrInvalidArgument
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	matrix := NewMatrixString(columns)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		err = matrix.AddRow(record)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return matrix, nil
+}
@@ -0,0 +1,45 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReadCSVString(t *testing.T) {
+	input := "name,city\n\"Smith, John\",Boston\nJane,\"Portland, \"\"OR\"\"\"\n"
+	matrix, err := ReadCSVString(strings.NewReader(input), 2)
+	if err != nil {
+		t.Errorf("read csv error: %+v", err)
+	}
+
+	if matrix.Rows() != 3 {
+		t.Errorf("row count %d is not 3", matrix.Rows())
+	}
+
+	if v, _ := matrix.GetValue(1, 0); v != "Smith, John" {
+		t.Errorf("value %q at (1, 0) is not %q", v, "Smith, John")
+	}
+
+	if v, _ := matrix.GetValue(2, 1); v != `Portland, "OR"` {
+		t.Errorf("value %q at (2, 1) is not %q", v, `Portland, "OR"`)
+	}
+
+	_, err = ReadCSVString(strings.NewReader("a,b\nc,d,e\n"), 2)
+	if err != ErrRowSize {
+		t.Errorf("ErrRowSize was not returned for a mismatched record")
+	}
+}
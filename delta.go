@@ -0,0 +1,159 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"math/bits"
+)
+
+const (
+	deltaMagic uint32 = 0x444d4648 // "HFMD"
+
+	// deltaZero is the control byte of a value that is
+	// unchanged from the previous row.
+	deltaZero byte = 0x80
+)
+
+// WriteDeltaEncoded will write the matrix to the writer with each value
+// stored as the change from the value in the same column of the previous
+// row, which is much smaller than WriteBinary for matrices whose columns
+// change slowly between rows. The header is the same as WriteBinary and
+// each value is then stored as:
+//
+//	control (byte), the leading and trailing zero byte counts of the
+//	    XOR of the value's bits with the previous value's bits
+//	the remaining bytes of the XOR, most significant first
+//
+// The matrix can be read back with ReadDeltaEncoded.
+func (m *MatrixFloat64) WriteDeltaEncoded(w io.Writer) error {
+	_, err := w.Write(m.binaryHeader(deltaMagic))
+	if err != nil {
+		return err
+	}
+
+	previous := make([]uint64, m.columns)
+	buf := make([]byte, 0, 9*m.columns)
+	for i := 0; i < len(m.data); i += m.columns {
+		buf = buf[:0]
+		for j, v := range m.data[i : i+m.columns] {
+			current := math.Float64bits(v)
+			buf = appendDelta(buf, current^previous[j])
+			previous[j] = current
+		}
+
+		_, err = w.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ReadDeltaEncoded will read a matrix that was written by WriteDeltaEncoded.
+// If the data does not begin with the expected magic number and
+// version then an ErrInvalidFormat will be returned, and if the
+// data ends before every value has been read then an ErrTruncated
+// will be returned.
+// Memory is only allocated for values as they are read, so a header
+// with corrupt dimensions cannot cause a large allocation.
+func ReadDeltaEncoded(r io.Reader) (*MatrixFloat64, error) {
+	columns, rows, err := readBinaryHeader(r, deltaMagic)
+	if err != nil {
+		return nil, err
+	}
+
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+
+	// each value is decoded against the value one row above it,
+	// which is zero for the first row
+	matrix := NewMatrixFloat64(columns)
+	n := int64(rows) * int64(columns)
+	for i := int64(0); i < n; i++ {
+		delta, err := readDelta(br)
+		if err != nil {
+			return nil, err
+		}
+
+		var previous uint64
+		if i >= int64(columns) {
+			previous = math.Float64bits(matrix.data[i-int64(columns)])
+		}
+		matrix.data = append(matrix.data, math.Float64frombits(previous^delta))
+	}
+
+	return matrix, nil
+}
+
+// appendDelta appends the encoding of the delta to the buffer.
+func appendDelta(buf []byte, delta uint64) []byte {
+	if delta == 0 {
+		return append(buf, deltaZero)
+	}
+
+	leading := bits.LeadingZeros64(delta) / 8
+	trailing := bits.TrailingZeros64(delta) / 8
+	buf = append(buf, byte(leading<<4|trailing))
+	for n := 7 - leading; n >= trailing; n-- {
+		buf = append(buf, byte(delta>>(8*uint(n))))
+	}
+
+	return buf
+}
+
+// readDelta reads a single delta that was encoded by appendDelta.
+func readDelta(r io.ByteReader) (uint64, error) {
+	control, err := readDeltaByte(r)
+	if err != nil {
+		return 0, err
+	}
+
+	if control == deltaZero {
+		return 0, nil
+	}
+
+	leading, trailing := int(control>>4), int(control&0x0f)
+	if leading+trailing > 7 {
+		return 0, ErrInvalidFormat
+	}
+
+	var delta uint64
+	for n := 7 - leading; n >= trailing; n-- {
+		b, err := readDeltaByte(r)
+		if err != nil {
+			return 0, err
+		}
+		delta |= uint64(b) << (8 * uint(n))
+	}
+
+	return delta, nil
+}
+
+// readDeltaByte reads a single byte, returning ErrTruncated
+// if the reader has ended.
+func readDeltaByte(r io.ByteReader) (byte, error) {
+	b, err := r.ReadByte()
+	if err == io.EOF {
+		return 0, ErrTruncated
+	}
+
+	return b, err
+}
@@ -0,0 +1,94 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func TestMatrixFloat64DeltaEncoded(t *testing.T) {
+	matrix := NewMatrixFloat64(3)
+	for i := 0; i < 100; i++ {
+		matrix.AddRow([]float64{float64(i), 20 + float64(i/10)*0.5, 1})
+	}
+	matrix.AddRow([]float64{-3.25, 1e300, 0})
+
+	var buf bytes.Buffer
+	err := matrix.WriteDeltaEncoded(&buf)
+	if err != nil {
+		t.Errorf("write delta encoded error: %+v", err)
+	}
+	encoded := buf.Bytes()
+
+	var raw bytes.Buffer
+	matrix.WriteBinary(&raw)
+	if len(encoded) >= raw.Len() {
+		t.Errorf("delta encoded size %d is not smaller than the raw size %d", len(encoded), raw.Len())
+	}
+
+	decoded, err := ReadDeltaEncoded(bytes.NewReader(encoded))
+	if err != nil {
+		t.Errorf("read delta encoded error: %+v", err)
+	}
+
+	if !decoded.Equal(matrix) {
+		t.Errorf("decoded matrix %v is not %v", decoded.data, matrix.data)
+	}
+
+	_, err = ReadDeltaEncoded(bytes.NewReader(raw.Bytes()))
+	if err != ErrInvalidFormat {
+		t.Errorf("ErrInvalidFormat was not returned for binary data")
+	}
+
+	_, err = ReadDeltaEncoded(bytes.NewReader(encoded[:len(encoded)-1]))
+	if err != ErrTruncated {
+		t.Errorf("ErrTruncated was not returned for truncated data")
+	}
+
+	corrupt := append([]byte{}, encoded[:binaryHeaderSize]...)
+	binary.LittleEndian.PutUint64(corrupt[8:], 1<<61)
+	_, err = ReadDeltaEncoded(bytes.NewReader(corrupt))
+	if err != ErrInvalidFormat {
+		t.Errorf("ErrInvalidFormat was not returned for an oversized column count: %v", err)
+	}
+
+	// large column counts that can be held are not allocated
+	// until their values have been read
+	for _, columns := range []uint64{1 << 50, uint64(maxValues)} {
+		binary.LittleEndian.PutUint64(corrupt[8:], columns)
+		binary.LittleEndian.PutUint64(corrupt[16:], 0)
+		empty, err := ReadDeltaEncoded(bytes.NewReader(corrupt))
+		if err != nil || empty.Rows() != 0 {
+			t.Errorf("matrix with %d columns and no rows was not read: %v", columns, err)
+		}
+
+		binary.LittleEndian.PutUint64(corrupt[16:], 1)
+		_, err = ReadDeltaEncoded(bytes.NewReader(corrupt))
+		if err != ErrTruncated {
+			t.Errorf("ErrTruncated was not returned for a row of %d columns: %v", columns, err)
+		}
+	}
+
+	// a matrix with no columns
+	var empty bytes.Buffer
+	noColumns, _ := FromRows(nil)
+	noColumns.WriteDeltaEncoded(&empty)
+	decoded, err = ReadDeltaEncoded(&empty)
+	if err != nil || decoded.Columns() != 0 || decoded.Rows() != 0 {
+		t.Errorf("matrix with no columns was not read: %v", err)
+	}
+}
@@ -17,7 +17,51 @@ package matrix
 import "fmt"
 
 var (
-	ErrRowSize     = fmt.Errorf("row has incorrect number of columns")
-	ErrRowIndex    = fmt.Errorf("row index is out of bounds")
-	ErrColumnIndex = fmt.Errorf("column index is out of bounds")
+	ErrRowSize           = fmt.Errorf("row has incorrect number of columns")
+	ErrRowIndex          = fmt.Errorf("row index is out of bounds")
+	ErrColumnIndex       = fmt.Errorf("column index is out of bounds")
+	ErrDimensionMismatch = fmt.Errorf("matrix dimensions do not match")
+	ErrEmptyMatrix       = fmt.Errorf("matrix has no rows")
+	ErrInsufficientRows  = fmt.Errorf("matrix does not have enough rows")
+	ErrInvalidArgument   = fmt.Errorf("argument is out of the valid range")
+	ErrInvalidFormat     = fmt.Errorf("data is not in a supported format")
+	ErrSingular          = fmt.Errorf("matrix is singular")
+	ErrTruncated         = fmt.Errorf("data ended unexpectedly")
+
+	// ErrNotSquare wraps ErrDimensionMismatch, so it can also
+	// be checked with errors.Is(err, ErrDimensionMismatch).
+	ErrNotSquare = fmt.Errorf("matrix is not square: %w", ErrDimensionMismatch)
+
+	// ErrMmapUnsupported is returned by OpenMmap on platforms
+	// that do not support memory-mapped files.
+	ErrMmapUnsupported = fmt.Errorf("memory-mapped files are not supported on this platform")
+)
+
+// Kinds of index that can be reported by an IndexError.
+const (
+	RowKind    = "row"
+	ColumnKind = "column"
 )
+
+// IndexError records an out of bounds row or column index
+// along with the bound that it exceeded.
+// It unwraps to ErrRowIndex or ErrColumnIndex depending on
+// its Kind so that errors.Is can be used to check for either.
+type IndexError struct {
+	Kind  string
+	Index int
+	Bound int
+}
+
+func (e *IndexError) Error() string {
+	return fmt.Sprintf("%s index %d is out of bounds [0, %d)", e.Kind, e.Index, e.Bound)
+}
+
+// Unwrap returns the sentinel error matching the kind of index.
+func (e *IndexError) Unwrap() error {
+	if e.Kind == ColumnKind {
+		return ErrColumnIndex
+	}
+
+	return ErrRowIndex
+}
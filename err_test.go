@@ -0,0 +1,104 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestErrors(t *testing.T) {
+	empty := NewMatrixFloat64(2)
+
+	_, err := empty.ColumnQuantile(0, 0.5)
+	if !errors.Is(err, ErrEmptyMatrix) {
+		t.Errorf("quantile of an empty matrix returned %v and not ErrEmptyMatrix", err)
+	}
+
+	_, _, err = empty.ColumnHistogram(0, 2)
+	if !errors.Is(err, ErrEmptyMatrix) {
+		t.Errorf("histogram of an empty matrix returned %v and not ErrEmptyMatrix", err)
+	}
+
+	_, err = empty.Covariance()
+	if !errors.Is(err, ErrEmptyMatrix) {
+		t.Errorf("covariance of an empty matrix returned %v and not ErrEmptyMatrix", err)
+	}
+
+	rectangular := NewMatrixFloat64(2)
+	rectangular.AddRow([]float64{1, 2})
+
+	_, err = rectangular.Trace()
+	if !errors.Is(err, ErrNotSquare) || !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("trace of a non-square matrix returned %v", err)
+	}
+
+	_, err = rectangular.VStack(NewMatrixFloat64(3))
+	if !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("vstack of mismatched matrices returned %v and not ErrDimensionMismatch", err)
+	}
+
+	_, err = rectangular.DotVec([]float64{1})
+	if !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("dot vec with a short vector returned %v and not ErrDimensionMismatch", err)
+	}
+
+	// ErrMmapUnsupported is defined on every platform so
+	// portable code can check for it
+	_, err = OpenMmap("testdata/missing.bin")
+	if err == nil {
+		t.Errorf("open mmap of a missing file did not return an error")
+	} else if errors.Is(err, ErrMmapUnsupported) {
+		t.Logf("memory-mapped files are not supported on this platform")
+	}
+}
+
+func TestIndexError(t *testing.T) {
+	matrix := NewMatrixFloat64(3)
+	matrix.AddRows([][]float64{{1, 2, 3}, {4, 5, 6}})
+
+	_, err := matrix.GetValue(2, 0)
+	if !errors.Is(err, ErrRowIndex) {
+		t.Errorf("get value of row 2 returned %v and not ErrRowIndex", err)
+	}
+
+	var indexErr *IndexError
+	if !errors.As(err, &indexErr) {
+		t.Fatalf("get value error %v is not an IndexError", err)
+	}
+
+	if indexErr.Kind != RowKind || indexErr.Index != 2 || indexErr.Bound != 2 {
+		t.Errorf("index error %+v does not describe row 2 of 2", indexErr)
+	}
+
+	err = matrix.UpdateValue(0, 1, -1)
+	if !errors.Is(err, ErrColumnIndex) || errors.Is(err, ErrRowIndex) {
+		t.Errorf("update value of column -1 returned %v and not ErrColumnIndex", err)
+	}
+
+	if !errors.As(err, &indexErr) || indexErr.Kind != ColumnKind || indexErr.Index != -1 || indexErr.Bound != 3 {
+		t.Errorf("index error %+v does not describe column -1 of 3", indexErr)
+	}
+
+	_, err = matrix.GetRow(5)
+	if !errors.As(err, &indexErr) || indexErr.Index != 5 {
+		t.Errorf("get row 5 returned %v", err)
+	}
+
+	_, err = NewMatrixBool(2).GetValue(0, 0)
+	if !errors.Is(err, ErrRowIndex) {
+		t.Errorf("get value of an empty bool matrix returned %v and not ErrRowIndex", err)
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// defaultPrecision is the number of digits after the
+// decimal point that String uses for each value.
+const defaultPrecision = 4
+
+// Format will return the matrix as text, with a header giving its
+// dimensions followed by one line for each row. The values of each
+// column are right-aligned and printed with the specified number of
+// digits after the decimal point. A negative precision prints each
+// value with the fewest digits that represent it exactly.
+func (m *MatrixFloat64) Format(precision int) string {
+	if precision < 0 {
+		precision = -1
+	}
+
+	rows := m.Rows()
+	cells := make([]string, len(m.data))
+	widths := make([]int, m.columns)
+	for i, v := range m.data {
+		cells[i] = strconv.FormatFloat(v, 'f', precision, 64)
+		if len(cells[i]) > widths[i%m.columns] {
+			widths[i%m.columns] = len(cells[i])
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "MatrixFloat64 (%d x %d)\n", rows, m.columns)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < m.columns; j++ {
+			if j > 0 {
+				b.WriteString("  ")
+			}
+			fmt.Fprintf(&b, "%*s", widths[j], cells[i*m.columns+j])
+		}
+		b.WriteByte('\n')
+	}
+
+	return b.String()
+}
+
+// String will return the matrix as text using Format
+// with 4 digits after the decimal point.
+func (m *MatrixFloat64) String() string {
+	return m.Format(defaultPrecision)
+}
+
+// ToMarkdown will return the matrix as a GitHub-flavored Markdown
+// table with one column for each column of the matrix. The headers
+// name the columns, and the index of each column is used instead when
+// no headers are provided. Pipes within headers are escaped.
+// If headers are provided and their count does not match the number
+// of columns then an ErrDimensionMismatch will be returned.
+func (m *MatrixFloat64) ToMarkdown(headers []string) (string, error) {
+	if len(headers) == 0 {
+		headers = make([]string, m.columns)
+		for j := range headers {
+			headers[j] = strconv.Itoa(j)
+		}
+	} else if len(headers) != m.columns {
+		return "", ErrDimensionMismatch
+	}
+
+	var b strings.Builder
+	b.WriteByte('|')
+	for _, header := range headers {
+		fmt.Fprintf(&b, " %s |", strings.Replace(header, "|", `\|`, -1))
+	}
+
+	b.WriteString("\n|")
+	for j := 0; j < m.columns; j++ {
+		b.WriteString(" ---: |")
+	}
+	b.WriteByte('\n')
+
+	for i := 0; i < len(m.data); i += m.columns {
+		b.WriteByte('|')
+		for _, v := range m.data[i : i+m.columns] {
+			fmt.Fprintf(&b, " %s |", strconv.FormatFloat(v, 'g', -1, 64))
+		}
+		b.WriteByte('\n')
+	}
+
+	return b.String(), nil
+}
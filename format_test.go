@@ -0,0 +1,74 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMatrixFloat64Format(t *testing.T) {
+	matrix := NewMatrixFloat64(3)
+	matrix.AddRows([][]float64{{1, -2.5, 100}, {10.25, 3, 0.5}})
+
+	expected := "MatrixFloat64 (2 x 3)\n" +
+		" 1.00  -2.50  100.00\n" +
+		"10.25   3.00    0.50\n"
+	if s := matrix.Format(2); s != expected {
+		t.Errorf("formatted matrix %q is not %q", s, expected)
+	}
+
+	expected = "MatrixFloat64 (2 x 3)\n" +
+		"    1  -2.5  100\n" +
+		"10.25     3  0.5\n"
+	if s := matrix.Format(-1); s != expected {
+		t.Errorf("formatted matrix %q is not %q", s, expected)
+	}
+
+	expected = "MatrixFloat64 (2 x 3)\n" +
+		" 1.0000  -2.5000  100.0000\n" +
+		"10.2500   3.0000    0.5000\n"
+	if s := matrix.String(); s != expected {
+		t.Errorf("string %q is not %q", s, expected)
+	}
+}
+
+func TestMatrixFloat64ToMarkdown(t *testing.T) {
+	matrix := NewMatrixFloat64(2)
+	matrix.AddRows([][]float64{{1, 0.5}, {-3, 1e21}})
+
+	table, err := matrix.ToMarkdown([]string{"id", "a|b"})
+	if err != nil {
+		t.Errorf("markdown error: %+v", err)
+	}
+
+	expected := "| id | a\\|b |\n" +
+		"| ---: | ---: |\n" +
+		"| 1 | 0.5 |\n" +
+		"| -3 | 1e+21 |\n"
+	if table != expected {
+		t.Errorf("markdown table %q is not %q", table, expected)
+	}
+
+	table, _ = matrix.ToMarkdown(nil)
+	if !strings.HasPrefix(table, "| 0 | 1 |\n") {
+		t.Errorf("markdown table %q does not have index headers", table)
+	}
+
+	_, err = matrix.ToMarkdown([]string{"id"})
+	if err != ErrDimensionMismatch {
+		t.Errorf("ErrDimensionMismatch was not returned for a short header")
+	}
+}
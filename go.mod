@@ -3,7 +3,8 @@ module github.com/humilityai/matrix
 go 1.13
 
 require (
+	github.com/apache/arrow/go/arrow v0.0.0-20211112161151-bc219186db40
 	github.com/humilityai/sam v0.0.0-20200926070415-163d9ceca42a
-	gonum.org/v1/gonum v0.7.0
+	gonum.org/v1/gonum v0.9.3
 	gorgonia.org/tensor v0.9.9
 )
@@ -0,0 +1,51 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+import (
+	"encoding/gob"
+	"io"
+
+	"github.com/humilityai/sam"
+)
+
+// StreamRowsGob will decode gob-encoded rows from the reader one at
+// a time and pass each row to the provided function, so that a
+// matrix never needs to be held in memory.
+// Decoding stops at the end of the reader or as soon as the function
+// returns an error, which is then returned.
+// If a decoded row does not have the specified number of columns then
+// an ErrRowSize will be returned.
+func StreamRowsGob(r io.Reader, columns int, fn func(row sam.SliceFloat64) error) error {
+	decoder := gob.NewDecoder(r)
+	for {
+		var row sam.SliceFloat64
+		err := decoder.Decode(&row)
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		if len(row) != columns {
+			return ErrRowSize
+		}
+
+		err = fn(row)
+		if err != nil {
+			return err
+		}
+	}
+}
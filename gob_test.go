@@ -0,0 +1,82 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"testing"
+
+	"github.com/humilityai/sam"
+)
+
+func TestStreamRowsGob(t *testing.T) {
+	rows := []sam.SliceFloat64{
+		{1, 2, 3},
+		{4, 5, 6},
+		{7, 8, 9},
+	}
+
+	var buf bytes.Buffer
+	encoder := gob.NewEncoder(&buf)
+	for _, row := range rows {
+		err := encoder.Encode(row)
+		if err != nil {
+			t.Errorf("encode error: %+v", err)
+		}
+	}
+	encoded := buf.Bytes()
+
+	var seen []sam.SliceFloat64
+	err := StreamRowsGob(bytes.NewReader(encoded), 3, func(row sam.SliceFloat64) error {
+		seen = append(seen, row)
+		return nil
+	})
+	if err != nil {
+		t.Errorf("stream error: %+v", err)
+	}
+
+	if len(seen) != len(rows) {
+		t.Errorf("saw %d rows and not %d", len(seen), len(rows))
+	}
+
+	for i, row := range seen {
+		if !row.Equal(rows[i]) {
+			t.Errorf("row %d is %v and not %v", i, row, rows[i])
+		}
+	}
+
+	// early termination
+	stop := fmt.Errorf("stop")
+	var count int
+	err = StreamRowsGob(bytes.NewReader(encoded), 3, func(row sam.SliceFloat64) error {
+		count++
+		if count == 2 {
+			return stop
+		}
+		return nil
+	})
+	if err != stop || count != 2 {
+		t.Errorf("stream did not stop after the callback error: %v after %d rows", err, count)
+	}
+
+	err = StreamRowsGob(bytes.NewReader(encoded), 2, func(row sam.SliceFloat64) error {
+		return nil
+	})
+	if err != ErrRowSize {
+		t.Errorf("ErrRowSize was not returned for mismatched columns")
+	}
+}
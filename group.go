@@ -0,0 +1,160 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+import (
+	"math"
+	"sort"
+
+	"github.com/humilityai/sam"
+)
+
+// GroupByColumn will group together the rows that share a value in
+// the key column and reduce each group to a single row with the
+// provided aggregation function. The function is given the rows of a
+// group, including the key column, and the groups are passed to it in
+// ascending order of their key.
+// If the key column is out of bounds then an ErrColumnIndex will be
+// returned, if the matrix has no rows then an ErrEmptyMatrix will be
+// returned, if the key column contains a NaN then an ErrInvalidArgument
+// will be returned, and if the function returns rows of differing
+// lengths then an ErrRowSize will be returned.
+func (m *MatrixFloat64) GroupByColumn(key int, agg func(rows *MatrixFloat64) []float64) (*MatrixFloat64, error) {
+	if key < 0 || key >= m.columns {
+		return nil, ErrColumnIndex
+	}
+
+	rows := m.Rows()
+	if rows == 0 {
+		return nil, ErrEmptyMatrix
+	}
+
+	groups, err := m.groupRows(key)
+	if err != nil {
+		return nil, err
+	}
+	keys := sortedGroupKeys(groups)
+
+	var result *MatrixFloat64
+	for _, k := range keys {
+		group := &MatrixFloat64{
+			data:    make(sam.SliceFloat64, 0, len(groups[k])*m.columns),
+			columns: m.columns,
+		}
+		for _, i := range groups[k] {
+			group.data = append(group.data, m.data[i*m.columns:(i+1)*m.columns]...)
+		}
+
+		row := agg(group)
+		if result == nil {
+			result = NewMatrixFloat64(len(row), len(keys))
+		}
+
+		err := result.AddRow(row)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// Pivot will turn a long matrix, where each row holds a row key, a
+// column key and a value, into a wide matrix with one row for each
+// distinct row key and one column for each distinct column key. Rows
+// and columns are in ascending order of their keys. Combinations that
+// do not appear are filled with zero, and when a combination appears
+// more than once the value of its last row is used.
+// If any of the columns is out of bounds then an ErrColumnIndex will
+// be returned, and if either key column contains a NaN then an
+// ErrInvalidArgument will be returned.
+func (m *MatrixFloat64) Pivot(rowKey, colKey, valueCol int) (*MatrixFloat64, error) {
+	for _, column := range []int{rowKey, colKey, valueCol} {
+		if column < 0 || column >= m.columns {
+			return nil, ErrColumnIndex
+		}
+	}
+
+	rowIndex, err := keyIndices(m.column(rowKey))
+	if err != nil {
+		return nil, err
+	}
+	colIndex, err := keyIndices(m.column(colKey))
+	if err != nil {
+		return nil, err
+	}
+
+	columns := len(colIndex)
+	pivot := &MatrixFloat64{
+		data:    make(sam.SliceFloat64, len(rowIndex)*columns),
+		columns: columns,
+	}
+
+	rows := m.Rows()
+	for i := 0; i < rows; i++ {
+		row := m.data[i*m.columns : (i+1)*m.columns]
+		pivot.data[rowIndex[row[rowKey]]*columns+colIndex[row[colKey]]] = row[valueCol]
+	}
+
+	return pivot, nil
+}
+
+// groupRows returns the indices of the rows that share each
+// value of the key column. A NaN can never be looked up in a
+// map, so a key column containing one is an ErrInvalidArgument.
+func (m *MatrixFloat64) groupRows(key int) (map[float64][]int, error) {
+	groups := make(map[float64][]int)
+	rows := m.Rows()
+	for i := 0; i < rows; i++ {
+		k := m.data[i*m.columns+key]
+		if math.IsNaN(k) {
+			return nil, ErrInvalidArgument
+		}
+		groups[k] = append(groups[k], i)
+	}
+
+	return groups, nil
+}
+
+// sortedGroupKeys returns the keys of the groups in ascending order.
+func sortedGroupKeys(groups map[float64][]int) []float64 {
+	keys := make([]float64, 0, len(groups))
+	for k := range groups {
+		keys = append(keys, k)
+	}
+	sort.Float64s(keys)
+
+	return keys
+}
+
+// keyIndices returns the position of each distinct value
+// when the distinct values are in ascending order. As with
+// groupRows, a NaN value is an ErrInvalidArgument.
+func keyIndices(values sam.SliceFloat64) (map[float64]int, error) {
+	distinct := make(map[float64][]int)
+	for _, v := range values {
+		if math.IsNaN(v) {
+			return nil, ErrInvalidArgument
+		}
+		distinct[v] = nil
+	}
+
+	indices := make(map[float64]int, len(distinct))
+	for i, k := range sortedGroupKeys(distinct) {
+		indices[k] = i
+	}
+
+	return indices, nil
+}
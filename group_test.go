@@ -0,0 +1,103 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMatrixFloat64GroupByColumn(t *testing.T) {
+	matrix := NewMatrixFloat64(2)
+	matrix.AddRows([][]float64{{1, 10}, {0, 5}, {1, 20}, {2, 7}, {0, 1}})
+
+	sums, err := matrix.GroupByColumn(0, func(rows *MatrixFloat64) []float64 {
+		key, _ := rows.GetValue(0, 0)
+		values, _ := rows.GetColumnData(1)
+		return []float64{key, values.Sum(), float64(rows.Rows())}
+	})
+	if err != nil {
+		t.Errorf("group by error: %+v", err)
+	}
+
+	expected := [][]float64{{0, 6, 2}, {1, 30, 2}, {2, 7, 1}}
+	if sums.Rows() != len(expected) || sums.Columns() != 3 {
+		t.Errorf("dimensions %dx%d are not 3x3", sums.Rows(), sums.Columns())
+	}
+	for i, row := range expected {
+		for j, v := range row {
+			if value, _ := sums.GetValue(i, j); value != v {
+				t.Errorf("value %v at (%d, %d) is not %v", value, i, j, v)
+			}
+		}
+	}
+
+	_, err = matrix.GroupByColumn(2, nil)
+	if err != ErrColumnIndex {
+		t.Errorf("ErrColumnIndex was not returned for an out of bounds key")
+	}
+
+	matrix.AddRow([]float64{math.NaN(), 3})
+	_, err = matrix.GroupByColumn(0, func(rows *MatrixFloat64) []float64 {
+		return []float64{0}
+	})
+	if err != ErrInvalidArgument {
+		t.Errorf("ErrInvalidArgument was not returned for a NaN key")
+	}
+}
+
+func TestMatrixFloat64Pivot(t *testing.T) {
+	// user, event, count
+	matrix := NewMatrixFloat64(3)
+	matrix.AddRows([][]float64{
+		{2, 1, 4},
+		{1, 0, 3},
+		{1, 1, 5},
+		{2, 2, 1},
+		{1, 0, 8},
+	})
+
+	pivot, err := matrix.Pivot(0, 1, 2)
+	if err != nil {
+		t.Errorf("pivot error: %+v", err)
+	}
+
+	expected := [][]float64{{8, 5, 0}, {0, 4, 1}}
+	if pivot.Rows() != 2 || pivot.Columns() != 3 {
+		t.Errorf("dimensions %dx%d are not 2x3", pivot.Rows(), pivot.Columns())
+	}
+	for i, row := range expected {
+		for j, v := range row {
+			if value, _ := pivot.GetValue(i, j); value != v {
+				t.Errorf("value %v at (%d, %d) is not %v", value, i, j, v)
+			}
+		}
+	}
+
+	_, err = matrix.Pivot(0, 1, 3)
+	if err != ErrColumnIndex {
+		t.Errorf("ErrColumnIndex was not returned for an out of bounds value column")
+	}
+
+	matrix.AddRow([]float64{1, math.NaN(), 2})
+	_, err = matrix.Pivot(0, 1, 2)
+	if err != ErrInvalidArgument {
+		t.Errorf("ErrInvalidArgument was not returned for a NaN column key")
+	}
+	_, err = matrix.Pivot(1, 0, 2)
+	if err != ErrInvalidArgument {
+		t.Errorf("ErrInvalidArgument was not returned for a NaN row key")
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+import (
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+	"math"
+)
+
+// Hash will return a 64-bit FNV-1a hash of the dimensions and values
+// of the matrix that can be used as a cache key. Matrices that are
+// Equal will always have the same hash.
+func (m *MatrixFloat64) Hash() uint64 {
+	h := fnv.New64a()
+
+	b := make([]byte, 16)
+	binary.LittleEndian.PutUint64(b, uint64(m.Rows()))
+	binary.LittleEndian.PutUint64(b[8:], uint64(m.columns))
+	h.Write(b)
+	writeFloat64s(h, m.data)
+
+	return h.Sum64()
+}
+
+// RowChecksum will return a 64-bit FNV-1a checksum of the values in
+// the row, which is stable between runs and can be used to detect rows
+// that have changed between snapshots of a matrix.
+// If the row is out of bounds then an ErrRowIndex will be returned.
+func (m *MatrixFloat64) RowChecksum(row int) (uint64, error) {
+	if row < 0 || row >= m.Rows() {
+		return 0, ErrRowIndex
+	}
+
+	h := fnv.New64a()
+	writeFloat64s(h, m.data[row*m.columns:(row+1)*m.columns])
+
+	return h.Sum64(), nil
+}
+
+// writeFloat64s writes the little-endian bits of each value to the
+// hash. Negative zero is written as zero so that values which compare
+// equal are hashed equally.
+func writeFloat64s(h hash.Hash64, values []float64) {
+	b := make([]byte, 8)
+	for _, v := range values {
+		if v == 0 {
+			v = 0
+		}
+		binary.LittleEndian.PutUint64(b, math.Float64bits(v))
+		h.Write(b)
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMatrixFloat64Hash(t *testing.T) {
+	matrix := NewMatrixFloat64(3)
+	matrix.AddRows([][]float64{{1, 2, 3}, {4, 5, 6}})
+
+	hash := matrix.Hash()
+	clone := matrix.Clone()
+	if clone.Hash() != hash {
+		t.Errorf("hash %d of the clone is not %d", clone.Hash(), hash)
+	}
+
+	clone.UpdateValue(7, 1, 2)
+	if clone.Hash() == hash {
+		t.Errorf("hash %d did not change after a cell change", clone.Hash())
+	}
+
+	reshaped := matrix.Clone()
+	reshaped.Reshape(2)
+	if reshaped.Hash() == hash {
+		t.Errorf("hash %d did not change after a reshape", reshaped.Hash())
+	}
+
+	zero := NewMatrixFloat64(1)
+	zero.AddRow([]float64{0})
+	negative := NewMatrixFloat64(1)
+	negative.AddRow([]float64{math.Copysign(0, -1)})
+	if zero.Hash() != negative.Hash() {
+		t.Errorf("hash %d of negative zero is not %d", negative.Hash(), zero.Hash())
+	}
+}
+
+func TestMatrixFloat64RowChecksum(t *testing.T) {
+	matrix := NewMatrixFloat64(3)
+	matrix.AddRows([][]float64{{1, 2, 3}, {4, 5, 6}, {1, 2, 3}})
+
+	first, err := matrix.RowChecksum(0)
+	if err != nil {
+		t.Errorf("row checksum error: %+v", err)
+	}
+
+	last, _ := matrix.RowChecksum(2)
+	if first != last {
+		t.Errorf("checksum %d of an identical row is not %d", last, first)
+	}
+
+	middle, _ := matrix.RowChecksum(1)
+	if first == middle {
+		t.Errorf("checksum %d of a different row is the same", middle)
+	}
+
+	// flip the lowest bit of a single value
+	matrix.UpdateValue(math.Float64frombits(math.Float64bits(2)^1), 2, 1)
+	changed, _ := matrix.RowChecksum(2)
+	if changed == first {
+		t.Errorf("checksum %d did not change after a single bit change", changed)
+	}
+
+	_, err = matrix.RowChecksum(3)
+	if err != ErrRowIndex {
+		t.Errorf("ErrRowIndex was not returned for an out of bounds row")
+	}
+}
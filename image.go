@@ -0,0 +1,66 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+// ToImage will create an image with one pixel for each value of the
+// matrix, where the pixel at (x, y) is the value at row y and column x.
+// Each value is normalized to [0, 1] between the smallest and largest
+// finite values of the matrix and passed to the palette to find its
+// color. Values that are not finite are normalized to NaN, and every
+// value is normalized to 0 if the finite values are all the same.
+// A grayscale palette from black to white is used if the palette is nil.
+func (m *MatrixFloat64) ToImage(palette func(normalized float64) color.Color) image.Image {
+	if palette == nil {
+		palette = grayscale
+	}
+
+	min, max := math.Inf(1), math.Inf(-1)
+	for _, v := range m.data {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			continue
+		}
+		min = math.Min(min, v)
+		max = math.Max(max, v)
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, m.columns, m.Rows()))
+	for i, v := range m.data {
+		normalized := 0.0
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			normalized = math.NaN()
+		} else if max > min {
+			normalized = (v - min) / (max - min)
+		}
+
+		img.Set(i%m.columns, i/m.columns, palette(normalized))
+	}
+
+	return img
+}
+
+// grayscale is the default palette of ToImage. NaN is black.
+func grayscale(normalized float64) color.Color {
+	if math.IsNaN(normalized) {
+		return color.Gray{}
+	}
+
+	return color.Gray{Y: uint8(math.Round(255 * math.Max(0, math.Min(1, normalized))))}
+}
@@ -0,0 +1,57 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestMatrixFloat64ToImage(t *testing.T) {
+	matrix := NewMatrixFloat64(3)
+	matrix.AddRows([][]float64{{0, 5, 10}, {2.5, 7.5, 10}})
+
+	img := matrix.ToImage(nil)
+	if img.Bounds() != image.Rect(0, 0, 3, 2) {
+		t.Errorf("image bounds %v are not 3x2", img.Bounds())
+	}
+
+	gray := color.GrayModel.Convert(img.At(0, 0)).(color.Gray)
+	if gray.Y != 0 {
+		t.Errorf("corner pixel %v is not black", gray)
+	}
+
+	gray = color.GrayModel.Convert(img.At(2, 1)).(color.Gray)
+	if gray.Y != 255 {
+		t.Errorf("corner pixel %v is not white", gray)
+	}
+
+	red := color.RGBA{R: 255, A: 255}
+	img = matrix.ToImage(func(normalized float64) color.Color {
+		if normalized > 0.5 {
+			return red
+		}
+		return color.Black
+	})
+
+	if r, g, b, _ := img.At(0, 1).RGBA(); r != 0 || g != 0 || b != 0 {
+		t.Errorf("pixel (0, 1) is not black")
+	}
+
+	if img.At(1, 1) != red {
+		t.Errorf("pixel (1, 1) %v is not %v", img.At(1, 1), red)
+	}
+}
@@ -43,6 +43,24 @@ func (i *Iterator) Next() bool {
 	return true
 }
 
+// Current will return the current row of a float64 matrix without
+// advancing the iterator. The boolean is false if the iterator is
+// not positioned on a row or the matrix does not hold float64 values.
+func (i *Iterator) Current() (sam.SliceFloat64, bool) {
+	if i.row < 0 || i.row >= i.Rows() {
+		return nil, false
+	}
+
+	r, err := i.GetRow(i.row)
+	if err != nil {
+		return nil, false
+	}
+
+	row, ok := r.(sam.SliceFloat64)
+
+	return row, ok
+}
+
 // Index returns the current index of the
 // iterator object.
 func (i *Iterator) Index() int {
@@ -54,6 +72,12 @@ func (i *Iterator) Index() int {
 	return row
 }
 
+// Reset will return the iterator to its initial position so
+// that the rows of the matrix can be traversed again.
+func (i *Iterator) Reset() {
+	i.row = -1
+}
+
 // Row will return the data of the current row for the iterator.
 func (i *Iterator) Row() sam.Slice {
 	row := i.row
@@ -106,3 +130,67 @@ func (i *Iterator) ApplyToColumns(f Func, columns sam.SliceInt) {
 		}
 	}
 }
+
+// ApplyWithRow will apply the supplied function to all values
+// in a float64 matrix. The function receives the entire current
+// row along with the column index and value being updated.
+// The row passed to the function holds the original values of
+// the row until every value in the row has been computed.
+// This should only be called after the Iterator has been created
+// and before the Next() method has been called
+func (i *Iterator) ApplyWithRow(f func(row sam.SliceFloat64, col int, v float64) float64) {
+	var values sam.SliceFloat64
+	for i.Next() {
+		row, ok := i.Row().(sam.SliceFloat64)
+		if !ok {
+			return
+		}
+
+		values = append(values[:0], row...)
+		for j, v := range row {
+			values[j] = f(row, j, v)
+		}
+		copy(row, values)
+	}
+}
+
+// BatchIterator is an object that can be used
+// to traverse the rows of a float64 matrix in
+// batches of consecutive rows exactly once.
+type BatchIterator struct {
+	matrix *MatrixFloat64
+	size   int
+	start  int
+	end    int
+}
+
+// Next will set the iterator to return the next batch.
+// It returns false once every row has been returned.
+func (b *BatchIterator) Next() bool {
+	rows := b.matrix.Rows()
+	if b.end >= rows {
+		return false
+	}
+
+	b.start = b.end
+	b.end += b.size
+	if b.end > rows {
+		b.end = rows
+	}
+
+	return true
+}
+
+// Batch will return the rows of the current batch as a matrix.
+// The batch shares its backing data with the original matrix, so
+// changes to its values are made in the original matrix.
+// The last batch may contain fewer rows than the batch size.
+func (b *BatchIterator) Batch() *MatrixFloat64 {
+	columns := b.matrix.columns
+	data := b.matrix.data[b.start*columns : b.end*columns]
+
+	return &MatrixFloat64{
+		data:    data[:len(data):len(data)],
+		columns: columns,
+	}
+}
@@ -15,7 +15,10 @@
 package matrix
 
 import (
+	"math"
 	"testing"
+
+	"github.com/humilityai/sam"
 )
 
 func TestMatrixFloat64Iterator(t *testing.T) {
@@ -211,3 +214,120 @@ func TestMatrixBoolIterator(t *testing.T) {
 		t.Errorf("value %v was not true", row.Get(0).(bool))
 	}
 }
+
+func TestIteratorApplyWithRow(t *testing.T) {
+	matrix := NewMatrixFloat64(3)
+	matrix.AddRow([]float64{1, 1, 2})
+	matrix.AddRow([]float64{2, 3, 5})
+
+	// row normalization
+	matrix.Iterator().ApplyWithRow(func(row sam.SliceFloat64, col int, v float64) float64 {
+		return v / row.Sum()
+	})
+
+	expected := [][]float64{
+		{0.25, 0.25, 0.5},
+		{0.2, 0.3, 0.5},
+	}
+	for i, row := range expected {
+		for j, e := range row {
+			v, _ := matrix.GetValue(i, j)
+			if math.Abs(v-e) > 1e-9 {
+				t.Errorf("value at (%d, %d) is %v and not %v", i, j, v, e)
+			}
+		}
+	}
+}
+
+func TestIteratorReset(t *testing.T) {
+	matrix := NewMatrixFloat64(2)
+	matrix.AddRows([][]float64{{1, 2}, {3, 4}, {5, 6}})
+
+	iter := matrix.Iterator()
+	for pass := 0; pass < 2; pass++ {
+		var count int
+		var sum float64
+		for iter.Next() {
+			count++
+			sum += iter.Row().(sam.SliceFloat64).Sum()
+		}
+
+		if count != 3 {
+			t.Errorf("pass %d saw %d rows and not 3", pass, count)
+		}
+
+		if sum != 21 {
+			t.Errorf("pass %d summed to %v and not 21", pass, sum)
+		}
+
+		iter.Reset()
+	}
+}
+
+func TestIteratorCurrent(t *testing.T) {
+	matrix := NewMatrixFloat64(2)
+	matrix.AddRows([][]float64{{1, 2}, {3, 4}})
+
+	iter := matrix.Iterator()
+	_, ok := iter.Current()
+	if ok {
+		t.Errorf("current row is valid before the first call to Next")
+	}
+
+	for iter.Next() {
+		first, ok := iter.Current()
+		if !ok {
+			t.Errorf("current row %d is not valid", iter.Index())
+		}
+
+		second, _ := iter.Current()
+		if !first.Equal(second) || !first.Equal(iter.Row()) {
+			t.Errorf("current row %v changed between calls", first)
+		}
+
+		if first[0] != float64(iter.Index()*2+1) {
+			t.Errorf("current row %v does not match index %d", first, iter.Index())
+		}
+	}
+
+	_, ok = iter.Current()
+	if ok {
+		t.Errorf("current row is valid after the last row")
+	}
+
+	_, ok = NewMatrixBool(1).Iterator().Current()
+	if ok {
+		t.Errorf("current row of a bool matrix is valid")
+	}
+}
+
+func TestBatchIterator(t *testing.T) {
+	matrix := NewMatrixFloat64(2)
+	for i := 0; i < 10; i++ {
+		matrix.AddRow([]float64{float64(i), float64(i)})
+	}
+
+	var sizes []int
+	var first []float64
+	batches := matrix.BatchIterator(4)
+	for batches.Next() {
+		batch := batches.Batch()
+		sizes = append(sizes, batch.Rows())
+		v, _ := batch.GetValue(0, 0)
+		first = append(first, v)
+	}
+
+	expected := []int{4, 4, 2}
+	if len(sizes) != len(expected) {
+		t.Errorf("batch sizes %v are not %v", sizes, expected)
+	}
+
+	for i, e := range expected {
+		if sizes[i] != e {
+			t.Errorf("batch %d has %d rows and not %d", i, sizes[i], e)
+		}
+		if first[i] != float64(i*4) {
+			t.Errorf("batch %d starts at row %v and not %d", i, first[i], i*4)
+		}
+	}
+}
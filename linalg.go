@@ -0,0 +1,409 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+import (
+	"math"
+
+	"github.com/humilityai/sam"
+	"gonum.org/v1/gonum/mat"
+)
+
+// CosineSimilarity will return the cosine of the angle between
+// the two specified rows of the matrix. If either row contains
+// only zeros then the similarity is 0.
+// If either row is out of bounds then an ErrRowIndex will be returned.
+func (m *MatrixFloat64) CosineSimilarity(a, b int) (float64, error) {
+	rows := m.Rows()
+	if a < 0 || a >= rows || b < 0 || b >= rows {
+		return 0, ErrRowIndex
+	}
+
+	x := m.data[a*m.columns : (a+1)*m.columns]
+	y := m.data[b*m.columns : (b+1)*m.columns]
+
+	var dot, xx, yy float64
+	for i, v := range x {
+		dot += v * y[i]
+		xx += v * v
+		yy += y[i] * y[i]
+	}
+
+	if xx == 0 || yy == 0 {
+		return 0, nil
+	}
+
+	return dot / (math.Sqrt(xx) * math.Sqrt(yy)), nil
+}
+
+// Determinant will return the determinant of the matrix, which is
+// computed from an LU decomposition.
+// If the matrix is not square then an ErrNotSquare will be returned
+// and if it has no rows then an ErrEmptyMatrix will be returned.
+func (m *MatrixFloat64) Determinant() (float64, error) {
+	rows := m.Rows()
+	if rows != m.columns {
+		return 0, ErrNotSquare
+	} else if rows == 0 {
+		return 0, ErrEmptyMatrix
+	}
+
+	return mat.Det(m.ToGonum()), nil
+}
+
+// Diagonal will return the values found on the main diagonal
+// of the matrix. The length of the diagonal is the smaller of
+// the number of rows and columns.
+func (m *MatrixFloat64) Diagonal() sam.SliceFloat64 {
+	diagonal := make(sam.SliceFloat64, m.diagonalLen())
+	for i := range diagonal {
+		diagonal[i] = m.data[i*m.columns+i]
+	}
+
+	return diagonal
+}
+
+// DotVec will return the product of the matrix and the vector.
+// The result has one value for each row of the matrix.
+// If the length of the vector does not match the number of
+// columns then an ErrDimensionMismatch will be returned.
+func (m *MatrixFloat64) DotVec(v sam.SliceFloat64) (sam.SliceFloat64, error) {
+	if len(v) != m.columns {
+		return nil, ErrDimensionMismatch
+	}
+
+	result := make(sam.SliceFloat64, m.Rows())
+	for i := range result {
+		var sum float64
+		for j, x := range m.data[i*m.columns : (i+1)*m.columns] {
+			sum += x * v[j]
+		}
+		result[i] = sum
+	}
+
+	return result, nil
+}
+
+// FrobeniusNorm will return the square root of the sum of
+// the squares of every value in the matrix.
+func (m *MatrixFloat64) FrobeniusNorm() float64 {
+	var sum float64
+	for _, v := range m.data {
+		sum += v * v
+	}
+
+	return math.Sqrt(sum)
+}
+
+// Inverse will return a new matrix that is the inverse of the
+// square matrix.
+// If the matrix is not square then an ErrNotSquare will be returned,
+// if it has no rows then an ErrEmptyMatrix will be returned,
+// and if the matrix is singular or too ill-conditioned to invert then
+// an ErrSingular will be returned.
+func (m *MatrixFloat64) Inverse() (*MatrixFloat64, error) {
+	lu, err := m.factorizeLU()
+	if err != nil {
+		return nil, err
+	}
+
+	identity := mat.NewDense(m.columns, m.columns, nil)
+	for i := 0; i < m.columns; i++ {
+		identity.Set(i, i, 1)
+	}
+
+	var inverse mat.Dense
+	err = lu.SolveTo(&inverse, false, identity)
+	if err != nil {
+		return nil, ErrSingular
+	}
+
+	return &MatrixFloat64{
+		data:    inverse.RawMatrix().Data,
+		columns: m.columns,
+	}, nil
+}
+
+// NormInf will return the infinity norm of the matrix,
+// which is the largest sum of absolute values of any row.
+func (m *MatrixFloat64) NormInf() float64 {
+	var norm float64
+	for i := 0; i < len(m.data); i += m.columns {
+		var sum float64
+		for _, v := range m.data[i : i+m.columns] {
+			sum += math.Abs(v)
+		}
+		if sum > norm {
+			norm = sum
+		}
+	}
+
+	return norm
+}
+
+// NormL1 will return the L1 norm of the matrix,
+// which is the largest sum of absolute values of any column.
+func (m *MatrixFloat64) NormL1() float64 {
+	sums := make(sam.SliceFloat64, m.columns)
+	for i, v := range m.data {
+		sums[i%m.columns] += math.Abs(v)
+	}
+
+	var norm float64
+	for _, sum := range sums {
+		if sum > norm {
+			norm = sum
+		}
+	}
+
+	return norm
+}
+
+// NormalizeRows will scale each row of the matrix in place so
+// that it has a Euclidean length of 1. Rows that contain only
+// zeros are left unchanged.
+func (m *MatrixFloat64) NormalizeRows() {
+	for i := 0; i < len(m.data); i += m.columns {
+		row := m.data[i : i+m.columns]
+
+		var sum float64
+		for _, v := range row {
+			sum += v * v
+		}
+
+		if sum == 0 {
+			continue
+		}
+
+		norm := math.Sqrt(sum)
+		for j := range row {
+			row[j] /= norm
+		}
+	}
+}
+
+// Outer creates a Matrix holding the outer product of the two
+// vectors. The value at row i and column j is a[i]*b[j].
+func Outer(a, b sam.SliceFloat64) *MatrixFloat64 {
+	data := make(sam.SliceFloat64, 0, len(a)*len(b))
+	for _, x := range a {
+		for _, y := range b {
+			data = append(data, x*y)
+		}
+	}
+
+	return &MatrixFloat64{
+		data:    data,
+		columns: len(b),
+	}
+}
+
+// PCA will mean-center the matrix and project it onto its top principal
+// components, which are found from an eigen-decomposition of the sample
+// covariance matrix. The projected matrix has one column for each component
+// and the explained variance of each component is returned in descending
+// order.
+// If the number of components is not between 1 and the number of columns
+// then an ErrInvalidArgument will be returned, and the errors of Covariance
+// are returned for a matrix with fewer than two rows.
+func (m *MatrixFloat64) PCA(components int) (transformed *MatrixFloat64, explainedVariance sam.SliceFloat64, err error) {
+	if components < 1 || components > m.columns {
+		return nil, nil, ErrInvalidArgument
+	}
+
+	covariance, err := m.Covariance()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var eigen mat.EigenSym
+	if !eigen.Factorize(mat.NewSymDense(m.columns, covariance.data), true) {
+		return nil, nil, ErrSingular
+	}
+
+	// eigenvalues are in ascending order so the top
+	// components are found at the end
+	values := eigen.Values(nil)
+	var vectors mat.Dense
+	eigen.VectorsTo(&vectors)
+
+	explainedVariance = make(sam.SliceFloat64, components)
+	basis := mat.NewDense(m.columns, components, nil)
+	for c := 0; c < components; c++ {
+		k := m.columns - 1 - c
+		explainedVariance[c] = values[k]
+		for j := 0; j < m.columns; j++ {
+			basis.Set(j, c, vectors.At(j, k))
+		}
+	}
+
+	rows := m.Rows()
+	means := m.ColumnMeans()
+	centered := mat.NewDense(rows, m.columns, nil)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < m.columns; j++ {
+			centered.Set(i, j, m.data[i*m.columns+j]-means[j])
+		}
+	}
+
+	var projected mat.Dense
+	projected.Mul(centered, basis)
+
+	return &MatrixFloat64{
+		data:    projected.RawMatrix().Data,
+		columns: components,
+	}, explainedVariance, nil
+}
+
+// PairwiseDistances will return a symmetric matrix holding the
+// Euclidean distance between every pair of rows in the matrix.
+// The value at row i and column j is the distance between rows
+// i and j, so the diagonal is always zero.
+func (m *MatrixFloat64) PairwiseDistances() *MatrixFloat64 {
+	rows := m.Rows()
+	data := make(sam.SliceFloat64, rows*rows)
+	for i := 0; i < rows; i++ {
+		a := m.data[i*m.columns : (i+1)*m.columns]
+		for j := i + 1; j < rows; j++ {
+			b := m.data[j*m.columns : (j+1)*m.columns]
+
+			var sum float64
+			for k, v := range a {
+				d := v - b[k]
+				sum += d * d
+			}
+
+			distance := math.Sqrt(sum)
+			data[i*rows+j] = distance
+			data[j*rows+i] = distance
+		}
+	}
+
+	return &MatrixFloat64{
+		data:    data,
+		columns: rows,
+	}
+}
+
+// Rank will return the numerical rank of the matrix, which is the
+// number of its singular values that are greater than the tolerance.
+// A matrix with no rows has a rank of 0.
+func (m *MatrixFloat64) Rank(tol float64) int {
+	if m.Rows() == 0 {
+		return 0
+	}
+
+	var svd mat.SVD
+	if !svd.Factorize(m.ToGonum(), mat.SVDNone) {
+		return 0
+	}
+
+	var rank int
+	for _, value := range svd.Values(nil) {
+		if value > tol {
+			rank++
+		}
+	}
+
+	return rank
+}
+
+// SetDiagonal will replace the values found on the main diagonal
+// of the matrix with the values provided.
+// If fewer values are provided than the length of the diagonal then
+// an ErrDimensionMismatch will be returned.
+func (m *MatrixFloat64) SetDiagonal(values sam.SliceFloat64) error {
+	n := m.diagonalLen()
+	if len(values) < n {
+		return ErrDimensionMismatch
+	}
+
+	for i := 0; i < n; i++ {
+		m.data[i*m.columns+i] = values[i]
+	}
+
+	return nil
+}
+
+// Solve will return the vector x that solves the linear system
+// m·x = b for a square matrix.
+// If the matrix is not square then an ErrNotSquare will be returned,
+// if it has no rows then an ErrEmptyMatrix will be returned,
+// if the length of b does not match the number of rows then an
+// ErrDimensionMismatch will be returned, and if the matrix is singular
+// or too ill-conditioned to solve then an ErrSingular will be returned.
+func (m *MatrixFloat64) Solve(b sam.SliceFloat64) (sam.SliceFloat64, error) {
+	lu, err := m.factorizeLU()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(b) != m.columns {
+		return nil, ErrDimensionMismatch
+	}
+
+	x := mat.NewVecDense(m.columns, nil)
+	err = lu.SolveVecTo(x, false, mat.NewVecDense(len(b), b))
+	if err != nil {
+		return nil, ErrSingular
+	}
+
+	return sam.SliceFloat64(x.RawVector().Data), nil
+}
+
+// Trace will return the sum of the values found on the
+// main diagonal of the matrix.
+// If the matrix is not square then an ErrNotSquare will be returned.
+func (m *MatrixFloat64) Trace() (float64, error) {
+	rows := m.Rows()
+	if rows != m.columns {
+		return 0, ErrNotSquare
+	}
+
+	var trace float64
+	for i := 0; i < rows; i++ {
+		trace += m.data[i*m.columns+i]
+	}
+
+	return trace, nil
+}
+
+func (m *MatrixFloat64) diagonalLen() int {
+	rows := m.Rows()
+	if rows < m.columns {
+		return rows
+	}
+
+	return m.columns
+}
+
+// factorizeLU returns the LU decomposition of a square matrix
+// that is well-conditioned enough to be solved.
+func (m *MatrixFloat64) factorizeLU() (*mat.LU, error) {
+	rows := m.Rows()
+	if rows != m.columns {
+		return nil, ErrNotSquare
+	} else if rows == 0 {
+		return nil, ErrEmptyMatrix
+	}
+
+	var lu mat.LU
+	lu.Factorize(m.ToGonum())
+	if lu.Cond() > mat.ConditionTolerance {
+		return nil, ErrSingular
+	}
+
+	return &lu, nil
+}
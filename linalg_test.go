@@ -0,0 +1,430 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/humilityai/sam"
+)
+
+func TestMatrixFloat64Trace(t *testing.T) {
+	matrix := NewMatrixFloat64(3)
+	matrix.AddRow([]float64{1, 2, 3})
+	matrix.AddRow([]float64{4, 5, 6})
+	matrix.AddRow([]float64{7, 8, 9})
+
+	trace, err := matrix.Trace()
+	if err != nil {
+		t.Errorf("trace error: %+v", err)
+	}
+
+	if trace != 15 {
+		t.Errorf("trace %v is not 15", trace)
+	}
+
+	matrix.AppendColumn(0)
+	_, err = matrix.Trace()
+	if err != ErrNotSquare {
+		t.Errorf("ErrNotSquare was not returned for a non-square matrix")
+	}
+}
+
+func TestMatrixFloat64Diagonal(t *testing.T) {
+	// more columns than rows
+	wide := NewMatrixFloat64(3)
+	wide.AddRow([]float64{1, 2, 3})
+	wide.AddRow([]float64{4, 5, 6})
+
+	diagonal := wide.Diagonal()
+	if !diagonal.Equal(sam.SliceFloat64{1, 5}) {
+		t.Errorf("diagonal %v is not [1 5]", diagonal)
+	}
+
+	err := wide.SetDiagonal(sam.SliceFloat64{-1, -5, -9})
+	if err != nil {
+		t.Errorf("set diagonal error: %+v", err)
+	}
+
+	diagonal = wide.Diagonal()
+	if !diagonal.Equal(sam.SliceFloat64{-1, -5}) {
+		t.Errorf("diagonal %v is not [-1 -5]", diagonal)
+	}
+
+	v, _ := wide.GetValue(0, 2)
+	if v != 3 {
+		t.Errorf("off-diagonal value %v was changed from 3", v)
+	}
+
+	// more rows than columns
+	tall := NewMatrixFloat64(2)
+	tall.AddRow([]float64{1, 2})
+	tall.AddRow([]float64{3, 4})
+	tall.AddRow([]float64{5, 6})
+
+	diagonal = tall.Diagonal()
+	if !diagonal.Equal(sam.SliceFloat64{1, 4}) {
+		t.Errorf("diagonal %v is not [1 4]", diagonal)
+	}
+
+	err = tall.SetDiagonal(sam.SliceFloat64{0})
+	if err != ErrDimensionMismatch {
+		t.Errorf("ErrDimensionMismatch was not returned for a short diagonal")
+	}
+}
+
+func TestMatrixFloat64DotVec(t *testing.T) {
+	matrix := NewMatrixFloat64(3)
+	matrix.AddRow([]float64{1, 2, 3})
+	matrix.AddRow([]float64{0, -1, 4})
+
+	result, err := matrix.DotVec(sam.SliceFloat64{2, 1, 0.5})
+	if err != nil {
+		t.Errorf("dot vec error: %+v", err)
+	}
+
+	if !result.Equal(sam.SliceFloat64{5.5, 1}) {
+		t.Errorf("product %v is not [5.5 1]", result)
+	}
+
+	_, err = matrix.DotVec(sam.SliceFloat64{1, 2})
+	if err != ErrDimensionMismatch {
+		t.Errorf("ErrDimensionMismatch was not returned for a short vector")
+	}
+}
+
+func TestOuter(t *testing.T) {
+	matrix := Outer(sam.SliceFloat64{1, 2, 3}, sam.SliceFloat64{4, 5})
+
+	r, c := matrix.Dimensions()
+	if r != 3 || c != 2 {
+		t.Errorf("outer product dimensions (%d, %d) are not (3, 2)", r, c)
+	}
+
+	v, _ := matrix.GetValue(0, 1)
+	if v != 5 {
+		t.Errorf("value at (0, 1) is %v and not 5", v)
+	}
+
+	v, _ = matrix.GetValue(2, 0)
+	if v != 12 {
+		t.Errorf("value at (2, 0) is %v and not 12", v)
+	}
+}
+
+func TestMatrixFloat64FrobeniusNorm(t *testing.T) {
+	matrix := NewMatrixFloat64(2)
+	matrix.AddRow([]float64{1, -2})
+	matrix.AddRow([]float64{2, 4})
+
+	norm := matrix.FrobeniusNorm()
+	if math.Abs(norm-5) > 1e-12 {
+		t.Errorf("frobenius norm %v is not 5", norm)
+	}
+
+	if NewMatrixFloat64(2).FrobeniusNorm() != 0 {
+		t.Errorf("frobenius norm of an empty matrix is not 0")
+	}
+}
+
+func TestMatrixFloat64NormL1NormInf(t *testing.T) {
+	matrix := NewMatrixFloat64(3)
+	matrix.AddRow([]float64{1, -7, 2})
+	matrix.AddRow([]float64{-3, 1, -8})
+
+	// column sums of absolute values: 4, 8, 10
+	if matrix.NormL1() != 10 {
+		t.Errorf("l1 norm %v is not 10", matrix.NormL1())
+	}
+
+	// row sums of absolute values: 10, 12
+	if matrix.NormInf() != 12 {
+		t.Errorf("infinity norm %v is not 12", matrix.NormInf())
+	}
+}
+
+func TestMatrixFloat64PairwiseDistances(t *testing.T) {
+	matrix := NewMatrixFloat64(2)
+	matrix.AddRow([]float64{0, 0})
+	matrix.AddRow([]float64{3, 4})
+	matrix.AddRow([]float64{0, 1})
+
+	distances := matrix.PairwiseDistances()
+
+	r, c := distances.Dimensions()
+	if r != 3 || c != 3 {
+		t.Errorf("distance dimensions (%d, %d) are not (3, 3)", r, c)
+	}
+
+	expected := [][]float64{
+		{0, 5, 1},
+		{5, 0, math.Sqrt(18)},
+		{1, math.Sqrt(18), 0},
+	}
+	for i, row := range expected {
+		for j, e := range row {
+			v, _ := distances.GetValue(i, j)
+			if math.Abs(v-e) > 1e-12 {
+				t.Errorf("distance at (%d, %d) is %v and not %v", i, j, v, e)
+			}
+		}
+	}
+}
+
+func TestMatrixFloat64CosineSimilarity(t *testing.T) {
+	matrix := NewMatrixFloat64(2)
+	matrix.AddRow([]float64{1, 0})
+	matrix.AddRow([]float64{0, 2})
+	matrix.AddRow([]float64{3, 0})
+	matrix.AddRow([]float64{0, 0})
+
+	similarity, err := matrix.CosineSimilarity(0, 1)
+	if err != nil {
+		t.Errorf("cosine similarity error: %+v", err)
+	}
+	if similarity != 0 {
+		t.Errorf("orthogonal similarity %v is not 0", similarity)
+	}
+
+	similarity, _ = matrix.CosineSimilarity(0, 2)
+	if math.Abs(similarity-1) > 1e-12 {
+		t.Errorf("identical direction similarity %v is not 1", similarity)
+	}
+
+	similarity, _ = matrix.CosineSimilarity(1, 1)
+	if math.Abs(similarity-1) > 1e-12 {
+		t.Errorf("self similarity %v is not 1", similarity)
+	}
+
+	similarity, err = matrix.CosineSimilarity(0, 3)
+	if err != nil || similarity != 0 {
+		t.Errorf("zero row similarity %v is not 0", similarity)
+	}
+
+	_, err = matrix.CosineSimilarity(0, 4)
+	if err != ErrRowIndex {
+		t.Errorf("ErrRowIndex was not returned for an out of bounds row")
+	}
+}
+
+func TestMatrixFloat64NormalizeRows(t *testing.T) {
+	matrix := NewMatrixFloat64(3)
+	matrix.AddRow([]float64{3, 0, 4})
+	matrix.AddRow([]float64{0, 0, 0})
+	matrix.AddRow([]float64{-1, 2, 2})
+
+	matrix.NormalizeRows()
+
+	for _, i := range []int{0, 2} {
+		var sum float64
+		for _, v := range matrix.Values(i) {
+			sum += v * v
+		}
+		if math.Abs(math.Sqrt(sum)-1) > 1e-12 {
+			t.Errorf("row %d has norm %v and not 1", i, math.Sqrt(sum))
+		}
+	}
+
+	if !sam.SliceFloat64(matrix.Values(1)).IsZeroed() {
+		t.Errorf("zero row was changed to %v", matrix.Values(1))
+	}
+
+	v, _ := matrix.GetValue(0, 2)
+	if math.Abs(v-0.8) > 1e-12 {
+		t.Errorf("normalized value %v is not 0.8", v)
+	}
+}
+
+func TestMatrixFloat64Determinant(t *testing.T) {
+	small := NewMatrixFloat64(2)
+	small.AddRows([][]float64{{3, 8}, {4, 6}})
+
+	det, err := small.Determinant()
+	if err != nil {
+		t.Errorf("determinant error: %+v", err)
+	}
+
+	if math.Abs(det-(-14)) > 1e-9 {
+		t.Errorf("determinant %v is not -14", det)
+	}
+
+	large := NewMatrixFloat64(3)
+	large.AddRows([][]float64{{6, 1, 1}, {4, -2, 5}, {2, 8, 7}})
+
+	det, _ = large.Determinant()
+	if math.Abs(det-(-306)) > 1e-9 {
+		t.Errorf("determinant %v is not -306", det)
+	}
+
+	wide := NewMatrixFloat64(3)
+	wide.AddRow([]float64{1, 2, 3})
+	_, err = wide.Determinant()
+	if err != ErrNotSquare {
+		t.Errorf("ErrNotSquare was not returned for a non-square matrix")
+	}
+
+	empty, _ := FromRows(nil)
+	_, err = empty.Determinant()
+	if err != ErrEmptyMatrix {
+		t.Errorf("ErrEmptyMatrix was not returned for a matrix with no rows")
+	}
+}
+
+func TestMatrixFloat64Solve(t *testing.T) {
+	matrix := NewMatrixFloat64(3)
+	matrix.AddRows([][]float64{{4, -2, 1}, {-2, 4, -2}, {1, -2, 4}})
+
+	x, err := matrix.Solve(sam.SliceFloat64{11, -16, 17})
+	if err != nil {
+		t.Errorf("solve error: %+v", err)
+	}
+
+	expected := []float64{1, -2, 3}
+	for i, v := range expected {
+		if math.Abs(x[i]-v) > 1e-9 {
+			t.Errorf("solution %v is not %v", x, expected)
+			break
+		}
+	}
+
+	_, err = matrix.Solve(sam.SliceFloat64{1, 2})
+	if err != ErrDimensionMismatch {
+		t.Errorf("ErrDimensionMismatch was not returned for a short vector")
+	}
+
+	singular := NewMatrixFloat64(3)
+	singular.AddRows([][]float64{{1, 2, 3}, {2, 4, 6}, {1, 0, 1}})
+	_, err = singular.Solve(sam.SliceFloat64{1, 2, 3})
+	if err != ErrSingular {
+		t.Errorf("ErrSingular was not returned for a singular matrix")
+	}
+
+	wide := NewMatrixFloat64(3)
+	wide.AddRow([]float64{1, 2, 3})
+	_, err = wide.Solve(sam.SliceFloat64{1})
+	if err != ErrNotSquare {
+		t.Errorf("ErrNotSquare was not returned for a non-square matrix")
+	}
+
+	empty, _ := FromRows(nil)
+	_, err = empty.Solve(sam.SliceFloat64{})
+	if err != ErrEmptyMatrix {
+		t.Errorf("ErrEmptyMatrix was not returned for a matrix with no rows")
+	}
+}
+
+func TestMatrixFloat64Inverse(t *testing.T) {
+	matrix := NewMatrixFloat64(3)
+	matrix.AddRows([][]float64{{2, -1, 0}, {-1, 2, -1}, {0, -1, 2}})
+
+	inverse, err := matrix.Inverse()
+	if err != nil {
+		t.Errorf("inverse error: %+v", err)
+	}
+
+	for j := 0; j < 3; j++ {
+		column, _ := inverse.GetColumnData(j)
+		product, _ := matrix.DotVec(column)
+		for i, v := range product {
+			expected := 0.0
+			if i == j {
+				expected = 1
+			}
+			if math.Abs(v-expected) > 1e-9 {
+				t.Errorf("product %v at (%d, %d) is not %v", v, i, j, expected)
+			}
+		}
+	}
+
+	singular := NewMatrixFloat64(2)
+	singular.AddRows([][]float64{{1, 2}, {2, 4}})
+	_, err = singular.Inverse()
+	if err != ErrSingular {
+		t.Errorf("ErrSingular was not returned for a singular matrix")
+	}
+
+	empty := NewMatrixFloat64(0)
+	_, err = empty.Inverse()
+	if err != ErrEmptyMatrix {
+		t.Errorf("ErrEmptyMatrix was not returned for a matrix with no rows")
+	}
+}
+
+func TestMatrixFloat64Rank(t *testing.T) {
+	matrix := NewMatrixFloat64(3)
+	matrix.AddRows([][]float64{{1, 0, 2}, {0, 1, 1}, {3, 1, 2}, {2, 2, 0}})
+
+	if rank := matrix.Rank(1e-10); rank != 3 {
+		t.Errorf("rank %d is not 3", rank)
+	}
+
+	// the third column is the sum of the first two
+	collinear := NewMatrixFloat64(3)
+	collinear.AddRows([][]float64{{1, 2, 3}, {4, 5, 9}, {7, 8, 15}, {2, 0, 2}})
+
+	if rank := collinear.Rank(1e-10); rank != 2 {
+		t.Errorf("rank %d of a rank-deficient matrix is not 2", rank)
+	}
+
+	if rank := NewMatrixFloat64(3).Rank(1e-10); rank != 0 {
+		t.Errorf("rank %d of an empty matrix is not 0", rank)
+	}
+}
+
+func TestMatrixFloat64PCA(t *testing.T) {
+	// the second column closely follows the first and the
+	// third column is small noise
+	matrix := NewMatrixFloat64(3)
+	matrix.AddRows([][]float64{
+		{1, 2.1, 0.1},
+		{2, 3.9, -0.1},
+		{3, 6.2, 0.05},
+		{4, 7.8, 0},
+		{5, 10.1, -0.05},
+		{6, 11.9, 0.1},
+	})
+
+	transformed, variance, err := matrix.PCA(2)
+	if err != nil {
+		t.Errorf("pca error: %+v", err)
+	}
+
+	if transformed.Rows() != 6 || transformed.Columns() != 2 {
+		t.Errorf("dimensions %dx%d are not 6x2", transformed.Rows(), transformed.Columns())
+	}
+
+	if len(variance) != 2 || variance[0] < variance[1] {
+		t.Errorf("explained variance %v is not descending", variance)
+	}
+
+	// the first component explains nearly all of the variance
+	covariance, _ := matrix.Covariance()
+	total, _ := covariance.Trace()
+	if variance[0]/total < 0.99 {
+		t.Errorf("explained variance ratio %v is not above 0.99", variance[0]/total)
+	}
+
+	// the projection of centered data has zero mean
+	first, _ := transformed.GetColumnData(0)
+	if math.Abs(first.Sum()) > 1e-9 {
+		t.Errorf("projected column sum %v is not 0", first.Sum())
+	}
+
+	_, _, err = matrix.PCA(4)
+	if err != ErrInvalidArgument {
+		t.Errorf("ErrInvalidArgument was not returned for too many components")
+	}
+}
@@ -51,19 +51,14 @@ func (m *MatrixBool) AddRow(row sam.SliceBool) error {
 // the specified default value into each row's column value.
 func (m *MatrixBool) AppendColumn(defaultValue bool) {
 	rows := m.Rows()
-	data := make(sam.SliceBool, len(m.data)+rows, len(m.data)+rows)
-
-	iter := m.Iterator()
-	for iter.Next() {
-		indices := iter.RowIndices()
-		for _, index := range indices {
-			data[index] = m.data[index]
-		}
-		lastIndex := indices[len(indices)-1]
-		data[lastIndex+1] = defaultValue
+	columns := m.columns + 1
+	data := make(sam.SliceBool, rows*columns)
+	for i := 0; i < rows; i++ {
+		copy(data[i*columns:], m.data[i*m.columns:(i+1)*m.columns])
+		data[i*columns+m.columns] = defaultValue
 	}
 
-	m.columns++
+	m.columns = columns
 	m.data = data
 }
 
@@ -87,8 +82,8 @@ func (m *MatrixBool) GetColumnData(column int) (data sam.SliceBool, err error) {
 		return data, ErrColumnIndex
 	}
 
-	for i := 0; i+m.columns < len(m.data)-1; i += m.columns {
-		data = append(data, m.data[i+column])
+	for i := column; i < len(m.data); i += m.columns {
+		data = append(data, m.data[i])
 	}
 
 	return
@@ -133,9 +128,11 @@ func (m *MatrixBool) Len() int {
 }
 
 // RemoveRow will delete the row from the matrix.
+// If the row is out of bounds then an *IndexError will be returned.
 func (m *MatrixBool) RemoveRow(row int) error {
-	if row < 0 || row > m.Rows() {
-		return ErrRowIndex
+	rows := m.Rows()
+	if row < 0 || row >= rows {
+		return &IndexError{Kind: RowKind, Index: row, Bound: rows}
 	}
 
 	start := row * m.columns
@@ -185,10 +182,10 @@ func (m *MatrixBool) UpdateValue(value bool, row, column int) error {
 
 func (m *MatrixBool) checkRowAndColumnBounds(row, column int) error {
 	rows := len(m.data) / m.columns
-	if row > rows || row < 0 {
-		return ErrRowIndex
-	} else if column < 0 || column > m.columns {
-		return ErrColumnIndex
+	if row >= rows || row < 0 {
+		return &IndexError{Kind: RowKind, Index: row, Bound: rows}
+	} else if column < 0 || column >= m.columns {
+		return &IndexError{Kind: ColumnKind, Index: column, Bound: m.columns}
 	}
 
 	return nil
@@ -15,6 +15,7 @@
 package matrix
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/humilityai/sam"
@@ -65,3 +66,45 @@ func TestMatrixBool(t *testing.T) {
 		t.Errorf("columns is %d and not %d", matrix.Columns(), columns+1)
 	}
 }
+
+func TestMatrixBoolAppendColumn(t *testing.T) {
+	matrix := NewMatrixBool(2)
+	rows := [][]bool{{true, false}, {false, false}, {true, true}}
+	for _, row := range rows {
+		matrix.AddRow(row)
+	}
+
+	matrix.AppendColumn(true)
+
+	if r, c := matrix.Dimensions(); r != 3 || c != 3 {
+		t.Errorf("dimensions %dx%d are not 3x3", r, c)
+	}
+
+	for i, row := range rows {
+		for j, v := range append(row, true) {
+			if value, _ := matrix.GetValue(i, j); value != v {
+				t.Errorf("value %v at (%d, %d) is not %v", value, i, j, v)
+			}
+		}
+	}
+}
+
+func TestMatrixBoolRemoveRow(t *testing.T) {
+	matrix := NewMatrixBool(1)
+	matrix.AddRow([]bool{true})
+	matrix.AddRow([]bool{false})
+
+	err := matrix.RemoveRow(0)
+	if err != nil {
+		t.Errorf("remove row error: %+v", err)
+	}
+
+	if v, _ := matrix.GetValue(0, 0); matrix.Rows() != 1 || v {
+		t.Errorf("the first row was not removed")
+	}
+
+	err = matrix.RemoveRow(1)
+	if !errors.Is(err, ErrRowIndex) {
+		t.Errorf("ErrRowIndex was not returned for an out of bounds row: %v", err)
+	}
+}
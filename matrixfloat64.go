@@ -17,6 +17,9 @@ package matrix
 import (
 	"math"
 	"math/rand"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/humilityai/sam"
 	"gonum.org/v1/gonum/mat"
@@ -27,17 +30,101 @@ import (
 type MatrixFloat64 struct {
 	data    sam.SliceFloat64
 	columns int
+
+	// mapped holds the memory-mapped region backing data
+	// when the matrix was created by OpenMmap.
+	mapped []byte
 }
 
 // NewMatrixFloat64 creates a Matrix with the specified column
-// count.
-func NewMatrixFloat64(columns int) *MatrixFloat64 {
+// count. An optional row capacity can be provided to reserve
+// space for that many rows up front.
+func NewMatrixFloat64(columns int, capacity ...int) *MatrixFloat64 {
+	var rows int
+	if len(capacity) > 0 && capacity[0] > 0 {
+		rows = capacity[0]
+	}
+
+	return &MatrixFloat64{
+		data:    make(sam.SliceFloat64, 0, rows*columns),
+		columns: columns,
+	}
+}
+
+// FromRows creates a Matrix from a copy of the float64 arrays,
+// with one row for each array. A matrix with no rows or columns
+// is returned if there are no arrays.
+// If the arrays are empty or do not all have the same size then
+// an ErrRowSize will be returned.
+func FromRows(rows [][]float64) (*MatrixFloat64, error) {
+	if len(rows) == 0 {
+		return NewMatrixFloat64(0), nil
+	} else if len(rows[0]) == 0 {
+		return nil, ErrRowSize
+	}
+
+	matrix := NewMatrixFloat64(len(rows[0]))
+	err := matrix.AddRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return matrix, nil
+}
+
+// OneHot creates a Matrix with one row for each label and one
+// column for each category. Each row holds a 1 in the column of
+// its label and 0 everywhere else.
+// If categories is not positive then an ErrInvalidArgument will be
+// returned, and if a label is not within [0, categories) then an
+// ErrColumnIndex will be returned.
+func OneHot(values sam.SliceInt, categories int) (*MatrixFloat64, error) {
+	if categories <= 0 {
+		return nil, ErrInvalidArgument
+	}
+
+	data := make(sam.SliceFloat64, len(values)*categories)
+	for i, label := range values {
+		if label < 0 || label >= categories {
+			return nil, ErrColumnIndex
+		}
+		data[i*categories+label] = 1
+	}
+
+	return &MatrixFloat64{
+		data:    data,
+		columns: categories,
+	}, nil
+}
+
+// Random creates a Matrix with the specified dimensions
+// filled with uniformly distributed values in [0, 1) drawn
+// from the provided source. If the source is nil then a new
+// source seeded with the current time is used.
+func Random(rows, columns int, r *rand.Rand) *MatrixFloat64 {
+	if r == nil {
+		r = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	data := make(sam.SliceFloat64, rows*columns)
+	for i := range data {
+		data[i] = r.Float64()
+	}
+
 	return &MatrixFloat64{
-		data:    make(sam.SliceFloat64, 0),
+		data:    data,
 		columns: columns,
 	}
 }
 
+// Abs will replace every value in the matrix
+// with its absolute value.
+func (m *MatrixFloat64) Abs() {
+	for i, v := range m.data {
+		m.data[i] = math.Abs(v)
+	}
+}
+
 // AddRow will append the float64 array to the matrix as a new row.
 // If the size of the row does not match the number of columns
 // in the matrix then an ErrRowSize will be returned.
@@ -51,10 +138,62 @@ func (m *MatrixFloat64) AddRow(row []float64) error {
 	return nil
 }
 
+// AddRows will append each of the float64 arrays to the matrix as new rows.
+// Every row is validated before any are appended, so if the size of any
+// row does not match the number of columns in the matrix then an
+// ErrRowSize will be returned and the matrix is left unchanged.
+func (m *MatrixFloat64) AddRows(rows [][]float64) error {
+	for _, row := range rows {
+		if len(row) != m.columns {
+			return ErrRowSize
+		}
+	}
+
+	m.Grow(len(rows))
+	for _, row := range rows {
+		m.data = append(m.data, row...)
+	}
+
+	return nil
+}
+
+// AddRowVector will add the vector to every row of the matrix
+// in place.
+// If the length of the vector does not match the number of
+// columns in the matrix then an ErrRowSize will be returned.
+func (m *MatrixFloat64) AddRowVector(v sam.SliceFloat64) error {
+	if len(v) != m.columns {
+		return ErrRowSize
+	}
+
+	for i := range m.data {
+		m.data[i] += v[i%m.columns]
+	}
+
+	return nil
+}
+
+// AppendMatrix will append all of the rows of the other matrix
+// to the matrix.
+// If the column counts of the two matrices differ then an
+// ErrDimensionMismatch will be returned.
+func (m *MatrixFloat64) AppendMatrix(other *MatrixFloat64) error {
+	if m.columns != other.columns {
+		return ErrDimensionMismatch
+	}
+
+	m.Grow(other.Rows())
+	m.data = append(m.data, other.data...)
+
+	return nil
+}
+
 // RemoveRow will delete the row from the matrix.
+// If the row is out of bounds then an *IndexError will be returned.
 func (m *MatrixFloat64) RemoveRow(row int) error {
-	if row < 0 || row > m.Rows() {
-		return ErrRowIndex
+	rows := m.Rows()
+	if row < 0 || row >= rows {
+		return &IndexError{Kind: RowKind, Index: row, Bound: rows}
 	}
 
 	start := row * m.columns
@@ -73,20 +212,92 @@ func (m *MatrixFloat64) Type() string {
 // the specified default value into each row's column value.
 func (m *MatrixFloat64) AppendColumn(defaultValue float64) {
 	rows := m.Rows()
-	data := make(sam.SliceFloat64, len(m.data)+rows, len(m.data)+rows)
+	columns := m.columns + 1
+	data := make(sam.SliceFloat64, rows*columns)
+	for i := 0; i < rows; i++ {
+		copy(data[i*columns:], m.data[i*m.columns:(i+1)*m.columns])
+		data[i*columns+m.columns] = defaultValue
+	}
 
-	iter := m.Iterator()
-	for iter.Next() {
-		indices := iter.RowIndices()
-		for _, index := range indices {
-			data[index] = m.data[index]
+	m.columns = columns
+	m.data = data
+}
+
+// ApplyDropout will set each value in the matrix to zero with
+// probability rate and scale every remaining value by 1/(1-rate)
+// in place. If the source is nil then a new source seeded with
+// the current time is used.
+// If the rate is not within [0, 1) then an ErrInvalidArgument
+// will be returned.
+func (m *MatrixFloat64) ApplyDropout(rate float64, r *rand.Rand) error {
+	if rate < 0 || rate >= 1 {
+		return ErrInvalidArgument
+	}
+
+	if r == nil {
+		r = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	scale := 1 / (1 - rate)
+	for i := range m.data {
+		if r.Float64() < rate {
+			m.data[i] = 0
+		} else {
+			m.data[i] *= scale
 		}
-		lastIndex := indices[len(indices)-1]
-		data[lastIndex+1] = defaultValue
 	}
 
-	m.columns++
-	m.data = data
+	return nil
+}
+
+// BatchIterator will return an object that allows the rows
+// of the matrix to be iterated in batches of the specified size.
+// A batch size less than 1 is treated as 1.
+func (m *MatrixFloat64) BatchIterator(batchSize int) *BatchIterator {
+	if batchSize < 1 {
+		batchSize = 1
+	}
+
+	return &BatchIterator{
+		matrix: m,
+		size:   batchSize,
+	}
+}
+
+// Cap will return the number of rows the backing array can hold
+// before another row added to the matrix causes a reallocation.
+// It complements Len, which returns the current number of rows.
+// A matrix with no columns cannot hold any rows.
+func (m *MatrixFloat64) Cap() int {
+	if m.columns == 0 {
+		return 0
+	}
+
+	return cap(m.data) / m.columns
+}
+
+// Clamp will limit every value in the matrix to the range
+// [min, max] in place. NaN values are left untouched.
+func (m *MatrixFloat64) Clamp(min, max float64) {
+	for i, v := range m.data {
+		if v < min {
+			m.data[i] = min
+		} else if v > max {
+			m.data[i] = max
+		}
+	}
+}
+
+// Clone will return a new matrix with a copy of the backing
+// data so that changes to the clone do not affect the original.
+func (m *MatrixFloat64) Clone() *MatrixFloat64 {
+	data := make(sam.SliceFloat64, len(m.data))
+	copy(data, m.data)
+
+	return &MatrixFloat64{
+		data:    data,
+		columns: m.columns,
+	}
 }
 
 // Columns will return the number of columns found
@@ -95,12 +306,98 @@ func (m *MatrixFloat64) Columns() int {
 	return m.columns
 }
 
+// CountNonFinite will return the number of NaN values
+// and the number of positive or negative infinite values
+// found in the matrix.
+func (m *MatrixFloat64) CountNonFinite() (nans int, infs int) {
+	for _, v := range m.data {
+		if math.IsNaN(v) {
+			nans++
+		} else if math.IsInf(v, 0) {
+			infs++
+		}
+	}
+
+	return
+}
+
 // Dimensions returns the number of rows and columns
 // in the matrix: (rows, columns).
 func (m *MatrixFloat64) Dimensions() (int, int) {
 	return m.Rows(), m.columns
 }
 
+// Each will call the function with the index and values of each row
+// of the matrix in order. The row shares the backing data of the
+// matrix, so changes to it change the matrix.
+// Iteration stops as soon as the function returns an error, which is
+// then returned.
+func (m *MatrixFloat64) Each(fn func(i int, row sam.SliceFloat64) error) error {
+	for i := 0; i*m.columns < len(m.data); i++ {
+		err := fn(i, m.data[i*m.columns:(i+1)*m.columns])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Equal will return true if the other matrix has the same
+// dimensions and exactly the same values as the matrix.
+func (m *MatrixFloat64) Equal(other *MatrixFloat64) bool {
+	if m.columns != other.columns || len(m.data) != len(other.data) {
+		return false
+	}
+
+	return m.data.Equal(other.data)
+}
+
+// EqualApprox will return true if the other matrix has the same
+// dimensions as the matrix and every pair of values differs by
+// no more than the tolerance.
+func (m *MatrixFloat64) EqualApprox(other *MatrixFloat64, tol float64) bool {
+	if m.columns != other.columns || len(m.data) != len(other.data) {
+		return false
+	}
+
+	for i, v := range m.data {
+		if math.Abs(v-other.data[i]) > tol {
+			return false
+		}
+	}
+
+	return true
+}
+
+// FlatMapRows will return a new matrix built from the rows returned
+// by the supplied function for each row of the matrix. The function
+// may return no rows to drop a row or several rows to expand it.
+// If any returned row does not have the same number of columns as the
+// matrix then an ErrRowSize will be returned.
+func (m *MatrixFloat64) FlatMapRows(f func(row sam.SliceFloat64) [][]float64) (*MatrixFloat64, error) {
+	result := NewMatrixFloat64(m.columns)
+	for i := 0; i < len(m.data); i += m.columns {
+		for _, row := range f(m.data[i : i+m.columns]) {
+			err := result.AddRow(row)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// Flatten will return a copy of the matrix values as a
+// single row-major array.
+func (m *MatrixFloat64) Flatten() sam.SliceFloat64 {
+	data := make(sam.SliceFloat64, len(m.data))
+	copy(data, m.data)
+
+	return data
+}
+
 // GetColumnData will return a float64 array that contains all the data points
 // of the specified column.
 // If the specified column is out of bounds then an ErrColumnIndex will be returned.
@@ -109,11 +406,7 @@ func (m *MatrixFloat64) GetColumnData(column int) (data sam.SliceFloat64, err er
 		return data, ErrColumnIndex
 	}
 
-	for i := 0; i+m.columns < len(m.data)-1; i += m.columns {
-		data = append(data, m.data[i+column])
-	}
-
-	return
+	return m.column(column), nil
 }
 
 // GetRow will retrieve the data at the given row index.
@@ -139,6 +432,104 @@ func (m *MatrixFloat64) GetValue(row, column int) (float64, error) {
 	return m.data[row*m.columns+column], nil
 }
 
+// GetValueRel will return the float64 value found at the row and
+// column arguments provided, where negative arguments count back from
+// the end of the matrix so that -1 is the last row or column.
+// It will return an *IndexError holding the argument as it was given
+// if either argument is out of bounds.
+func (m *MatrixFloat64) GetValueRel(row, column int) (float64, error) {
+	r, c := row, column
+	if r < 0 {
+		r += m.Rows()
+	}
+	if c < 0 {
+		c += m.columns
+	}
+
+	err := m.checkRowAndColumnBounds(r, c)
+	if err != nil {
+		indexErr := err.(*IndexError)
+		if indexErr.Kind == RowKind {
+			indexErr.Index = row
+		} else {
+			indexErr.Index = column
+		}
+		return 0, indexErr
+	}
+
+	return m.data[r*m.columns+c], nil
+}
+
+// Grow will reserve capacity in the backing array for the
+// specified number of additional rows so that subsequent
+// calls to AddRow do not need to reallocate.
+func (m *MatrixFloat64) Grow(rows int) {
+	if rows <= 0 {
+		return
+	}
+
+	needed := len(m.data) + rows*m.columns
+	if needed <= cap(m.data) {
+		return
+	}
+
+	data := make(sam.SliceFloat64, len(m.data), needed)
+	copy(data, m.data)
+	m.data = data
+}
+
+// HStack will return a new matrix that joins the columns of the
+// other matrix to the right side of the columns of the matrix.
+// If the row counts of the two matrices differ then an
+// ErrDimensionMismatch will be returned.
+func (m *MatrixFloat64) HStack(other *MatrixFloat64) (*MatrixFloat64, error) {
+	rows := m.Rows()
+	if rows != other.Rows() {
+		return nil, ErrDimensionMismatch
+	}
+
+	columns := m.columns + other.columns
+	data := make(sam.SliceFloat64, 0, rows*columns)
+	for i := 0; i < rows; i++ {
+		data = append(data, m.data[i*m.columns:(i+1)*m.columns]...)
+		data = append(data, other.data[i*other.columns:(i+1)*other.columns]...)
+	}
+
+	return &MatrixFloat64{
+		data:    data,
+		columns: columns,
+	}, nil
+}
+
+// InsertColumn will add a column to the matrix before the column at
+// the specified index, shifting that column and every later column to
+// the right, and place the specified default value into each row's
+// column value. An index equal to the number of columns appends the
+// column.
+// If the index is not within [0, Columns()] then an ErrColumnIndex
+// will be returned.
+func (m *MatrixFloat64) InsertColumn(index int, defaultValue float64) error {
+	if index < 0 || index > m.columns {
+		return ErrColumnIndex
+	}
+
+	rows := m.Rows()
+	columns := m.columns + 1
+	data := make(sam.SliceFloat64, rows*columns)
+	for i := 0; i < rows; i++ {
+		row := m.data[i*m.columns : (i+1)*m.columns]
+		start := i * columns
+		copy(data[start:], row[:index])
+		data[start+index] = defaultValue
+		copy(data[start+index+1:], row[index:])
+	}
+
+	m.columns = columns
+	m.data = data
+
+	return nil
+}
+
 // Iterator will return an object that allows row
 // iteration of the matrix.
 func (m *MatrixFloat64) Iterator() *Iterator {
@@ -148,6 +539,44 @@ func (m *MatrixFloat64) Iterator() *Iterator {
 	}
 }
 
+// KFold will randomly partition the row indices of the matrix into
+// k folds of roughly equal size using the provided source. If the
+// source is nil then a new source seeded with the current time is used.
+// If k is less than 2 or greater than the number of rows then an
+// ErrInvalidArgument will be returned.
+func (m *MatrixFloat64) KFold(k int, r *rand.Rand) ([][]int, error) {
+	rows := m.Rows()
+	if k < 2 || k > rows {
+		return nil, ErrInvalidArgument
+	}
+
+	if r == nil {
+		r = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	folds := make([][]int, k)
+	for i, row := range r.Perm(rows) {
+		folds[i%k] = append(folds[i%k], row)
+	}
+
+	return folds, nil
+}
+
+// Map will return a new matrix holding the result of applying
+// the supplied function to every value of the matrix. The
+// original matrix is not modified.
+func (m *MatrixFloat64) Map(f Func) *MatrixFloat64 {
+	data := make(sam.SliceFloat64, len(m.data))
+	for i, v := range m.data {
+		data[i] = f(v).(float64)
+	}
+
+	return &MatrixFloat64{
+		data:    data,
+		columns: m.columns,
+	}
+}
+
 // MaxSum will return the row with the greatest sum
 // of its components.
 func (m *MatrixFloat64) MaxSum() sam.SliceFloat64 {
@@ -180,29 +609,41 @@ func (m *MatrixFloat64) MinSum() sam.SliceFloat64 {
 	return min
 }
 
-// Mode will return the "mode" of each column as
-// a "vector" or "row".
+// Mode will return the most frequently occurring row
+// of the matrix. If several rows occur equally often then
+// the one that appears first in the matrix is returned.
 func (m *MatrixFloat64) Mode() sam.SliceFloat64 {
 	var uniques sam.SliceInt
 	uniqueCounts := make(sam.MapIntInt)
 
-	for i := 0; i+m.columns < len(m.data)-1; i += m.columns {
-		row := m.data[i : i+m.columns]
+	for i := 0; i < len(m.data); i += m.columns {
+		row := sam.SliceFloat64(m.data[i : i+m.columns])
 		var exists bool
 		for _, index := range uniques {
-			if sam.SliceFloat64(row).Equal(sam.SliceFloat64(m.data[index : index+m.columns])) {
+			if row.Equal(sam.SliceFloat64(m.data[index : index+m.columns])) {
 				uniqueCounts[index]++
 				exists = true
+				break
 			}
 		}
-		if exists == false {
+		if !exists {
+			uniques = append(uniques, i)
 			uniqueCounts[i] = 1
 		}
 	}
 
-	k, _ := uniqueCounts.MaxValue()
+	if len(uniques) == 0 {
+		return sam.SliceFloat64{}
+	}
 
-	return m.data[k : k+m.columns]
+	mode := uniques[0]
+	for _, index := range uniques {
+		if uniqueCounts[index] > uniqueCounts[mode] {
+			mode = index
+		}
+	}
+
+	return m.data[mode : mode+m.columns]
 }
 
 // Len is a standard method that satisfies
@@ -235,9 +676,127 @@ func (m *MatrixFloat64) NonZeroRows() (*MatrixFloat64, error) {
 	return matrix, nil
 }
 
+// ParallelRowSums will return the sum of the components of each
+// row in the matrix, splitting the rows between the specified number
+// of goroutines. The sums are returned in row order.
+// A worker count less than 1 is treated as 1.
+func (m *MatrixFloat64) ParallelRowSums(workers int) sam.SliceFloat64 {
+	rows := m.Rows()
+	sums := make(sam.SliceFloat64, rows)
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > rows {
+		workers = rows
+	}
+	if rows == 0 {
+		return sums
+	}
+
+	var wg sync.WaitGroup
+	chunk := (rows + workers - 1) / workers
+	for start := 0; start < rows; start += chunk {
+		end := start + chunk
+		if end > rows {
+			end = rows
+		}
+
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			for i := start; i < end; i++ {
+				sums[i] = sam.SliceFloat64(m.data[i*m.columns : (i+1)*m.columns]).Sum()
+			}
+		}(start, end)
+	}
+	wg.Wait()
+
+	return sums
+}
+
+// ReplaceNonFinite will overwrite every NaN and infinite value
+// in the matrix with the replacement value. It returns the number
+// of values that were replaced.
+func (m *MatrixFloat64) ReplaceNonFinite(replacement float64) int {
+	var count int
+	for i, v := range m.data {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			m.data[i] = replacement
+			count++
+		}
+	}
+
+	return count
+}
+
+// Reshape will change the number of columns in the matrix
+// without copying the backing data, so the same values are
+// reinterpreted as rows of the new width.
+// If the number of values in the matrix is not divisible by
+// the new column count then an ErrDimensionMismatch will be returned.
+func (m *MatrixFloat64) Reshape(columns int) error {
+	if columns <= 0 || len(m.data)%columns != 0 {
+		return ErrDimensionMismatch
+	}
+
+	m.columns = columns
+
+	return nil
+}
+
+// RowArgmax will return the column index of the largest
+// value in each row of the matrix. If a row contains the
+// largest value more than once then the first index is used.
+func (m *MatrixFloat64) RowArgmax() sam.SliceInt {
+	indices := make(sam.SliceInt, m.Rows())
+	for i := range indices {
+		row := m.data[i*m.columns : (i+1)*m.columns]
+		for j, v := range row {
+			if v > row[indices[i]] {
+				indices[i] = j
+			}
+		}
+	}
+
+	return indices
+}
+
+// RowArgmin will return the column index of the smallest
+// value in each row of the matrix. If a row contains the
+// smallest value more than once then the first index is used.
+func (m *MatrixFloat64) RowArgmin() sam.SliceInt {
+	indices := make(sam.SliceInt, m.Rows())
+	for i := range indices {
+		row := m.data[i*m.columns : (i+1)*m.columns]
+		for j, v := range row {
+			if v < row[indices[i]] {
+				indices[i] = j
+			}
+		}
+	}
+
+	return indices
+}
+
+// RowSums will return the sum of the components
+// of each row in the matrix.
+func (m *MatrixFloat64) RowSums() sam.SliceFloat64 {
+	sums := make(sam.SliceFloat64, m.Rows())
+	for i := range sums {
+		sums[i] = sam.SliceFloat64(m.data[i*m.columns : (i+1)*m.columns]).Sum()
+	}
+
+	return sums
+}
+
 // Rows will return the number of rows found
-// in the matrix.
+// in the matrix. A matrix with no columns
+// has no rows.
 func (m *MatrixFloat64) Rows() int {
+	if m.columns == 0 {
+		return 0
+	}
+
 	return len(m.data) / m.columns
 }
 
@@ -269,24 +828,288 @@ func (m *MatrixFloat64) Sample(amount int) *MatrixFloat64 {
 	return sample
 }
 
+// SelectColumns will return a new matrix that contains only the
+// specified columns in the order they are provided. A column may be
+// selected more than once.
+// If any column is out of bounds then an ErrColumnIndex will be returned.
+func (m *MatrixFloat64) SelectColumns(cols []int) (*MatrixFloat64, error) {
+	for _, column := range cols {
+		if column < 0 || column >= m.columns {
+			return nil, ErrColumnIndex
+		}
+	}
+
+	rows := m.Rows()
+	data := make(sam.SliceFloat64, 0, rows*len(cols))
+	for i := 0; i < rows; i++ {
+		start := i * m.columns
+		for _, column := range cols {
+			data = append(data, m.data[start+column])
+		}
+	}
+
+	return &MatrixFloat64{
+		data:    data,
+		columns: len(cols),
+	}, nil
+}
+
+// SelectRows will return a new matrix that contains only the
+// specified rows in the order they are provided. A row may be
+// selected more than once.
+// If any row is out of bounds then an ErrRowIndex will be returned.
+func (m *MatrixFloat64) SelectRows(rows []int) (*MatrixFloat64, error) {
+	count := m.Rows()
+	for _, row := range rows {
+		if row < 0 || row >= count {
+			return nil, ErrRowIndex
+		}
+	}
+
+	data := make(sam.SliceFloat64, 0, len(rows)*m.columns)
+	for _, row := range rows {
+		start := row * m.columns
+		data = append(data, m.data[start:start+m.columns]...)
+	}
+
+	return &MatrixFloat64{
+		data:    data,
+		columns: m.columns,
+	}, nil
+}
+
 // SetBackingData will replace the matrix backing array with the
 // array provided.
+// The length of the array is not checked. If it is not a multiple
+// of the number of columns then row and value lookups will be
+// incorrect; use SetBackingDataChecked to validate the array.
 func (m *MatrixFloat64) SetBackingData(data sam.SliceFloat64) {
 	m.data = data
 }
 
+// SetBackingDataChecked will replace the matrix backing array with the
+// array provided. If the length of the array is not a multiple of the
+// number of columns then an ErrRowSize will be returned and the
+// matrix is left unchanged. A matrix with no columns only accepts
+// an empty array.
+func (m *MatrixFloat64) SetBackingDataChecked(data sam.SliceFloat64) error {
+	if m.columns == 0 {
+		if len(data) != 0 {
+			return ErrRowSize
+		}
+	} else if len(data)%m.columns != 0 {
+		return ErrRowSize
+	}
+
+	m.data = data
+
+	return nil
+}
+
+// SetColumnData will replace every value of the specified column
+// with the values provided, in row order.
+// If the column is out of bounds then an ErrColumnIndex will be returned
+// and if the number of values does not match the number of rows then
+// an ErrDimensionMismatch will be returned.
+func (m *MatrixFloat64) SetColumnData(column int, data sam.SliceFloat64) error {
+	if column < 0 || column >= m.columns {
+		return ErrColumnIndex
+	} else if len(data) != m.Rows() {
+		return ErrDimensionMismatch
+	}
+
+	for i, v := range data {
+		m.data[i*m.columns+column] = v
+	}
+
+	return nil
+}
+
+// Shuffle will randomly reorder the rows of the matrix in place
+// using the provided source. If the source is nil then a new
+// source seeded with the current time is used.
+func (m *MatrixFloat64) Shuffle(r *rand.Rand) {
+	if r == nil {
+		r = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	tmp := make(sam.SliceFloat64, m.columns)
+	for i := m.Rows() - 1; i > 0; i-- {
+		j := r.Intn(i + 1)
+		if i == j {
+			continue
+		}
+
+		a := m.data[i*m.columns : (i+1)*m.columns]
+		b := m.data[j*m.columns : (j+1)*m.columns]
+		copy(tmp, a)
+		copy(a, b)
+		copy(b, tmp)
+	}
+}
+
+// Slice will return a new matrix that contains a copy of the block
+// of values found between the half-open row range [rowStart, rowEnd)
+// and column range [colStart, colEnd).
+// If a row bound is invalid then an ErrRowIndex will be returned and
+// if a column bound is invalid then an ErrColumnIndex will be returned.
+func (m *MatrixFloat64) Slice(rowStart, rowEnd, colStart, colEnd int) (*MatrixFloat64, error) {
+	if rowStart < 0 || rowEnd > m.Rows() || rowStart > rowEnd {
+		return nil, ErrRowIndex
+	} else if colStart < 0 || colEnd > m.columns || colStart > colEnd {
+		return nil, ErrColumnIndex
+	}
+
+	columns := colEnd - colStart
+	data := make(sam.SliceFloat64, 0, (rowEnd-rowStart)*columns)
+	for i := rowStart; i < rowEnd; i++ {
+		start := i * m.columns
+		data = append(data, m.data[start+colStart:start+colEnd]...)
+	}
+
+	return &MatrixFloat64{
+		data:    data,
+		columns: columns,
+	}, nil
+}
+
+// SoftmaxRows will apply the softmax function to each row
+// of the matrix in place. The largest value of each row is
+// subtracted before exponentiating to avoid overflow.
+func (m *MatrixFloat64) SoftmaxRows() {
+	for i := 0; i < len(m.data); i += m.columns {
+		row := m.data[i : i+m.columns]
+		max := row[0]
+		for _, v := range row {
+			if v > max {
+				max = v
+			}
+		}
+
+		var sum float64
+		for j, v := range row {
+			row[j] = math.Exp(v - max)
+			sum += row[j]
+		}
+
+		for j := range row {
+			row[j] /= sum
+		}
+	}
+}
+
+// SortRows will reorder the rows of the matrix by the values found
+// in the specified column. Entire rows are moved and rows with equal
+// values keep their original order.
+// If the column is out of bounds then an ErrColumnIndex will be returned.
+func (m *MatrixFloat64) SortRows(column int, ascending bool) error {
+	if column < 0 || column >= m.columns {
+		return ErrColumnIndex
+	}
+
+	rows := m.Rows()
+	order := make([]int, rows)
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		a := m.data[order[i]*m.columns+column]
+		b := m.data[order[j]*m.columns+column]
+		if ascending {
+			return a < b
+		}
+		return a > b
+	})
+
+	data := make(sam.SliceFloat64, 0, len(m.data))
+	for _, row := range order {
+		start := row * m.columns
+		data = append(data, m.data[start:start+m.columns]...)
+	}
+	m.data = data
+
+	return nil
+}
+
+// SubtractRowVector will subtract the vector from every row of
+// the matrix in place.
+// If the length of the vector does not match the number of
+// columns in the matrix then an ErrRowSize will be returned.
+func (m *MatrixFloat64) SubtractRowVector(v sam.SliceFloat64) error {
+	if len(v) != m.columns {
+		return ErrRowSize
+	}
+
+	for i := range m.data {
+		m.data[i] -= v[i%m.columns]
+	}
+
+	return nil
+}
+
+// ToBool will create and return a new *MatrixBool with the same
+// dimensions as the MatrixFloat64. Each value in the new matrix is
+// true if the original value is greater than or equal to the threshold.
+func (m *MatrixFloat64) ToBool(threshold float64) *MatrixBool {
+	data := make(sam.SliceBool, len(m.data), len(m.data))
+	for i, v := range m.data {
+		data[i] = v >= threshold
+	}
+
+	return &MatrixBool{
+		data:    data,
+		columns: m.columns,
+	}
+}
+
 // ToGonum will create and return a new Gonum Mat64 object
 // from the MatrixFloat64
 func (m *MatrixFloat64) ToGonum() mat.Matrix {
 	return mat.NewDense(m.Rows(), m.Columns(), m.data)
 }
 
+// ToRows will return a copy of the matrix as a float64 array
+// for each row.
+func (m *MatrixFloat64) ToRows() [][]float64 {
+	rows := make([][]float64, m.Rows())
+	for i := range rows {
+		rows[i] = make([]float64, m.columns)
+		copy(rows[i], m.data[i*m.columns:(i+1)*m.columns])
+	}
+
+	return rows
+}
+
 // ToTensor will create and return a new Gorgonia Tensor (dense) object
 // from the MatrixFloat64.
 func (m *MatrixFloat64) ToTensor() tensor.Tensor {
 	return tensor.NewDense(tensor.Float64, []int{m.Rows(), m.Columns()}, tensor.WithBacking(m.data))
 }
 
+// Unique will return a new matrix with duplicate rows removed.
+// The first occurrence of each row is kept and the original
+// row order is preserved.
+func (m *MatrixFloat64) Unique() *MatrixFloat64 {
+	unique := NewMatrixFloat64(m.columns)
+
+	for i := 0; i < len(m.data); i += m.columns {
+		row := sam.SliceFloat64(m.data[i : i+m.columns])
+		var exists bool
+		for j := 0; j < len(unique.data); j += m.columns {
+			if row.Equal(sam.SliceFloat64(unique.data[j : j+m.columns])) {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			unique.data = append(unique.data, row...)
+		}
+	}
+
+	return unique
+}
+
 // UpdateValue will update the value found at the provided row and column
 // arguments.
 // If the row or column are out of bounds for the matrix then the proper
@@ -302,12 +1125,97 @@ func (m *MatrixFloat64) UpdateValue(value float64, row, column int) error {
 	return nil
 }
 
+// VStack will return a new matrix that contains the rows of the matrix
+// followed by the rows of the other matrix.
+// If the column counts of the two matrices differ then an
+// ErrDimensionMismatch will be returned.
+func (m *MatrixFloat64) VStack(other *MatrixFloat64) (*MatrixFloat64, error) {
+	if m.columns != other.columns {
+		return nil, ErrDimensionMismatch
+	}
+
+	data := make(sam.SliceFloat64, 0, len(m.data)+len(other.data))
+	data = append(data, m.data...)
+	data = append(data, other.data...)
+
+	return &MatrixFloat64{
+		data:    data,
+		columns: m.columns,
+	}, nil
+}
+
+// WeightedSample will return a new matrix of n rows drawn with
+// replacement from the matrix, where each row is chosen with a
+// probability proportional to its weight. If the source is nil
+// then a new source seeded with the current time is used.
+// If the number of weights does not match the number of rows then an
+// ErrDimensionMismatch will be returned. If n is negative, a weight is
+// negative, NaN or infinite, or the weights sum to zero or overflow
+// then an ErrInvalidArgument will be returned.
+func (m *MatrixFloat64) WeightedSample(n int, weights sam.SliceFloat64, r *rand.Rand) (*MatrixFloat64, error) {
+	if len(weights) != m.Rows() {
+		return nil, ErrDimensionMismatch
+	} else if n < 0 {
+		return nil, ErrInvalidArgument
+	}
+
+	cumulative := make(sam.SliceFloat64, len(weights))
+	var total float64
+	for i, w := range weights {
+		if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			return nil, ErrInvalidArgument
+		}
+		total += w
+		cumulative[i] = total
+	}
+
+	if total == 0 || math.IsInf(total, 0) {
+		return nil, ErrInvalidArgument
+	}
+
+	if r == nil {
+		r = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	sample := NewMatrixFloat64(m.columns, n)
+	for i := 0; i < n; i++ {
+		target := r.Float64() * total
+		row := sort.Search(len(cumulative), func(j int) bool {
+			return cumulative[j] > target
+		})
+
+		// the target can round up to the total, in which case
+		// the last row with a positive weight is chosen
+		if row == len(cumulative) {
+			row--
+			for weights[row] == 0 {
+				row--
+			}
+		}
+		sample.data = append(sample.data, m.data[row*m.columns:(row+1)*m.columns]...)
+	}
+
+	return sample, nil
+}
+
 func (m *MatrixFloat64) checkRowAndColumnBounds(row, column int) error {
-	if row > m.Rows() || row < 0 {
-		return ErrRowIndex
-	} else if column < 0 || column > m.columns {
-		return ErrColumnIndex
+	rows := m.Rows()
+	if row >= rows || row < 0 {
+		return &IndexError{Kind: RowKind, Index: row, Bound: rows}
+	} else if column < 0 || column >= m.columns {
+		return &IndexError{Kind: ColumnKind, Index: column, Bound: m.columns}
 	}
 
 	return nil
 }
+
+// column returns a copy of the values in the column.
+// The column is assumed to be within bounds.
+func (m *MatrixFloat64) column(column int) sam.SliceFloat64 {
+	data := make(sam.SliceFloat64, 0, m.Rows())
+	for i := column; i < len(m.data); i += m.columns {
+		data = append(data, m.data[i])
+	}
+
+	return data
+}
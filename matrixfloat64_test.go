@@ -15,6 +15,9 @@
 package matrix
 
 import (
+	"errors"
+	"math"
+	"math/rand"
 	"testing"
 
 	"github.com/humilityai/sam"
@@ -65,3 +68,1328 @@ func TestMatrixFloat64(t *testing.T) {
 		t.Errorf("columns is %d and not %d", matrix.Columns(), columns+1)
 	}
 }
+
+func TestMatrixFloat64ToBool(t *testing.T) {
+	matrix := NewMatrixFloat64(3)
+
+	err := matrix.AddRow([]float64{0.25, 0.5, 0.75})
+	if err != nil {
+		t.Errorf("matrix row add error: %+v", err)
+	}
+
+	err = matrix.AddRow([]float64{0.4999, 0.5001, 0})
+	if err != nil {
+		t.Errorf("matrix row add error: %+v", err)
+	}
+
+	b := matrix.ToBool(0.5)
+
+	r, c := b.Dimensions()
+	if r != 2 || c != 3 {
+		t.Errorf("bool matrix dimensions (%d, %d) are not (2, 3)", r, c)
+	}
+
+	expected := [][]bool{
+		{false, true, true},
+		{false, true, false},
+	}
+	for i, row := range expected {
+		for j, e := range row {
+			v, err := b.GetValue(i, j)
+			if err != nil {
+				t.Errorf("get value error: %+v", err)
+			}
+			if v != e {
+				t.Errorf("value at (%d, %d) is %v and not %v", i, j, v, e)
+			}
+		}
+	}
+}
+
+func TestMatrixFloat64VStack(t *testing.T) {
+	top := NewMatrixFloat64(3)
+	top.AddRow([]float64{1, 2, 3})
+	top.AddRow([]float64{4, 5, 6})
+
+	bottom := NewMatrixFloat64(3)
+	bottom.AddRow([]float64{7, 8, 9})
+
+	stacked, err := top.VStack(bottom)
+	if err != nil {
+		t.Errorf("vstack error: %+v", err)
+	}
+
+	r, c := stacked.Dimensions()
+	if r != 3 || c != 3 {
+		t.Errorf("stacked dimensions (%d, %d) are not (3, 3)", r, c)
+	}
+
+	for i := 0; i < 3; i++ {
+		v, _ := stacked.GetValue(i, 0)
+		if v != float64(i*3+1) {
+			t.Errorf("row %d starts with %v and not %v", i, v, float64(i*3+1))
+		}
+	}
+
+	_, err = top.VStack(NewMatrixFloat64(2))
+	if err != ErrDimensionMismatch {
+		t.Errorf("ErrDimensionMismatch was not returned for mismatched columns")
+	}
+}
+
+func TestMatrixFloat64HStack(t *testing.T) {
+	left := NewMatrixFloat64(2)
+	left.AddRow([]float64{1, 2})
+	left.AddRow([]float64{4, 5})
+	left.AddRow([]float64{7, 8})
+
+	right := NewMatrixFloat64(1)
+	right.AddRow([]float64{3})
+	right.AddRow([]float64{6})
+	right.AddRow([]float64{9})
+
+	joined, err := left.HStack(right)
+	if err != nil {
+		t.Errorf("hstack error: %+v", err)
+	}
+
+	r, c := joined.Dimensions()
+	if r != 3 || c != 3 {
+		t.Errorf("joined dimensions (%d, %d) are not (3, 3)", r, c)
+	}
+
+	cells := []struct {
+		row, column int
+		value       float64
+	}{
+		{0, 0, 1},
+		{0, 2, 3},
+		{1, 1, 5},
+		{2, 2, 9},
+	}
+	for _, cell := range cells {
+		v, _ := joined.GetValue(cell.row, cell.column)
+		if v != cell.value {
+			t.Errorf("value at (%d, %d) is %v and not %v", cell.row, cell.column, v, cell.value)
+		}
+	}
+
+	short := NewMatrixFloat64(1)
+	short.AddRow([]float64{0})
+	_, err = left.HStack(short)
+	if err != ErrDimensionMismatch {
+		t.Errorf("ErrDimensionMismatch was not returned for mismatched rows")
+	}
+}
+
+func TestMatrixFloat64Slice(t *testing.T) {
+	matrix := NewMatrixFloat64(4)
+	for i := 0; i < 4; i++ {
+		row := make([]float64, 4)
+		for j := range row {
+			row[j] = float64(i*4 + j)
+		}
+		matrix.AddRow(row)
+	}
+
+	// top-left corner
+	corner, err := matrix.Slice(0, 2, 0, 2)
+	if err != nil {
+		t.Errorf("slice error: %+v", err)
+	}
+
+	r, c := corner.Dimensions()
+	if r != 2 || c != 2 {
+		t.Errorf("corner dimensions (%d, %d) are not (2, 2)", r, c)
+	}
+
+	for i, e := range []float64{0, 1, 4, 5} {
+		if corner.data[i] != e {
+			t.Errorf("corner value %v at index %d is not %v", corner.data[i], i, e)
+		}
+	}
+
+	// interior
+	interior, err := matrix.Slice(1, 3, 1, 4)
+	if err != nil {
+		t.Errorf("slice error: %+v", err)
+	}
+
+	r, c = interior.Dimensions()
+	if r != 2 || c != 3 {
+		t.Errorf("interior dimensions (%d, %d) are not (2, 3)", r, c)
+	}
+
+	for i, e := range []float64{5, 6, 7, 9, 10, 11} {
+		if interior.data[i] != e {
+			t.Errorf("interior value %v at index %d is not %v", interior.data[i], i, e)
+		}
+	}
+
+	// the slice is a copy
+	interior.UpdateValue(-1, 0, 0)
+	v, _ := matrix.GetValue(1, 1)
+	if v != 5 {
+		t.Errorf("original value changed to %v after updating the slice", v)
+	}
+
+	// out of range
+	_, err = matrix.Slice(0, 5, 0, 2)
+	if err != ErrRowIndex {
+		t.Errorf("ErrRowIndex was not returned for an out of range row")
+	}
+
+	_, err = matrix.Slice(0, 2, 3, 2)
+	if err != ErrColumnIndex {
+		t.Errorf("ErrColumnIndex was not returned for an inverted column range")
+	}
+}
+
+func TestMatrixFloat64SelectColumns(t *testing.T) {
+	matrix := NewMatrixFloat64(3)
+	matrix.AddRow([]float64{1, 2, 3})
+	matrix.AddRow([]float64{4, 5, 6})
+	matrix.AddRow([]float64{7, 8, 9})
+
+	selected, err := matrix.SelectColumns([]int{2, 0})
+	if err != nil {
+		t.Errorf("select columns error: %+v", err)
+	}
+
+	if selected.Columns() != 2 {
+		t.Errorf("selected columns %d is not 2", selected.Columns())
+	}
+
+	for i, original := range []int{2, 0} {
+		for row := 0; row < matrix.Rows(); row++ {
+			expected, _ := matrix.GetValue(row, original)
+			v, _ := selected.GetValue(row, i)
+			if v != expected {
+				t.Errorf("selected value %v at (%d, %d) is not %v", v, row, i, expected)
+			}
+		}
+	}
+
+	_, err = matrix.SelectColumns([]int{0, 3})
+	if err != ErrColumnIndex {
+		t.Errorf("ErrColumnIndex was not returned for an out of bounds column")
+	}
+}
+
+func TestMatrixFloat64SelectRows(t *testing.T) {
+	matrix := NewMatrixFloat64(2)
+	for i := 0; i < 4; i++ {
+		matrix.AddRow([]float64{float64(i), float64(i * 10)})
+	}
+
+	selected, err := matrix.SelectRows([]int{3, 1, 1})
+	if err != nil {
+		t.Errorf("select rows error: %+v", err)
+	}
+
+	if selected.Rows() != 3 {
+		t.Errorf("selected rows %d is not 3", selected.Rows())
+	}
+
+	for i, original := range []int{3, 1, 1} {
+		v, _ := selected.GetValue(i, 0)
+		if v != float64(original) {
+			t.Errorf("selected row %d starts with %v and not %v", i, v, float64(original))
+		}
+		v, _ = selected.GetValue(i, 1)
+		if v != float64(original*10) {
+			t.Errorf("selected row %d ends with %v and not %v", i, v, float64(original*10))
+		}
+	}
+
+	_, err = matrix.SelectRows([]int{4})
+	if err != ErrRowIndex {
+		t.Errorf("ErrRowIndex was not returned for an out of bounds row")
+	}
+}
+
+func TestMatrixFloat64SortRows(t *testing.T) {
+	matrix := NewMatrixFloat64(3)
+	matrix.AddRow([]float64{0, 2, 20})
+	matrix.AddRow([]float64{1, 5, 50})
+	matrix.AddRow([]float64{2, 1, 10})
+	matrix.AddRow([]float64{3, 5, 50})
+
+	err := matrix.SortRows(1, false)
+	if err != nil {
+		t.Errorf("sort rows error: %+v", err)
+	}
+
+	// rows 1 and 3 tie on the key column and keep their order
+	expected := []float64{1, 3, 0, 2}
+	for i, e := range expected {
+		row, _ := matrix.GetRow(i)
+		values := row.(sam.SliceFloat64)
+		if values[0] != e {
+			t.Errorf("row %d is original row %v and not %v", i, values[0], e)
+		}
+		if values[2] != values[1]*10 {
+			t.Errorf("row %d was not moved intact: %v", i, values)
+		}
+	}
+
+	err = matrix.SortRows(3, true)
+	if err != ErrColumnIndex {
+		t.Errorf("ErrColumnIndex was not returned for an out of bounds column")
+	}
+}
+
+func TestMatrixFloat64Unique(t *testing.T) {
+	matrix := NewMatrixFloat64(2)
+	matrix.AddRow([]float64{1, 1})
+	matrix.AddRow([]float64{2, 2})
+	matrix.AddRow([]float64{1, 1})
+	matrix.AddRow([]float64{3, 3})
+	matrix.AddRow([]float64{2, 2})
+	matrix.AddRow([]float64{1, 1})
+
+	unique := matrix.Unique()
+	if unique.Rows() != 3 {
+		t.Errorf("unique rows %d is not 3", unique.Rows())
+	}
+
+	for i, e := range []float64{1, 2, 3} {
+		v, _ := unique.GetValue(i, 0)
+		if v != e {
+			t.Errorf("unique row %d is %v and not %v", i, v, e)
+		}
+	}
+
+	if matrix.Rows() != 6 {
+		t.Errorf("original matrix was modified")
+	}
+}
+
+func TestMatrixFloat64Mode(t *testing.T) {
+	matrix := NewMatrixFloat64(2)
+	matrix.AddRow([]float64{1, 1})
+	matrix.AddRow([]float64{2, 2})
+	matrix.AddRow([]float64{3, 3})
+	matrix.AddRow([]float64{2, 2})
+	matrix.AddRow([]float64{1, 1})
+	matrix.AddRow([]float64{2, 2})
+
+	mode := matrix.Mode()
+	if !mode.Equal(sam.SliceFloat64{2, 2}) {
+		t.Errorf("mode %v is not [2 2]", mode)
+	}
+
+	// ties are broken by first occurrence
+	tied := NewMatrixFloat64(1)
+	tied.AddRow([]float64{5})
+	tied.AddRow([]float64{4})
+	tied.AddRow([]float64{4})
+	tied.AddRow([]float64{5})
+
+	mode = tied.Mode()
+	if !mode.Equal(sam.SliceFloat64{5}) {
+		t.Errorf("tied mode %v is not [5]", mode)
+	}
+}
+
+func TestMatrixFloat64RowSums(t *testing.T) {
+	matrix := NewMatrixFloat64(3)
+	matrix.AddRow([]float64{1, 2, 3})
+	matrix.AddRow([]float64{-1, 0, 1})
+	matrix.AddRow([]float64{0.5, 0.25, 0.25})
+
+	sums := matrix.RowSums()
+	expected := sam.SliceFloat64{6, 0, 1}
+	if !sums.Equal(expected) {
+		t.Errorf("row sums %v are not %v", sums, expected)
+	}
+}
+
+func TestRandom(t *testing.T) {
+	a := Random(4, 3, rand.New(rand.NewSource(42)))
+	b := Random(4, 3, rand.New(rand.NewSource(42)))
+
+	r, c := a.Dimensions()
+	if r != 4 || c != 3 {
+		t.Errorf("random dimensions (%d, %d) are not (4, 3)", r, c)
+	}
+
+	for i, v := range a.data {
+		if v < 0 || v >= 1 {
+			t.Errorf("random value %v is not in [0, 1)", v)
+		}
+		if v != b.data[i] {
+			t.Errorf("random values %v and %v differ for the same seed", v, b.data[i])
+		}
+	}
+
+	if Random(2, 2, nil).Rows() != 2 {
+		t.Errorf("random matrix with default source does not have 2 rows")
+	}
+}
+
+func TestMatrixFloat64Equal(t *testing.T) {
+	a := NewMatrixFloat64(2)
+	a.AddRow([]float64{1, 2})
+	a.AddRow([]float64{3, 4})
+
+	b := NewMatrixFloat64(2)
+	b.AddRow([]float64{1, 2})
+	b.AddRow([]float64{3, 4})
+
+	if !a.Equal(b) {
+		t.Errorf("matrices with the same values are not equal")
+	}
+
+	// same values, different dimensions
+	c := NewMatrixFloat64(4)
+	c.AddRow([]float64{1, 2, 3, 4})
+
+	if a.Equal(c) {
+		t.Errorf("matrices with different dimensions are equal")
+	}
+
+	if a.EqualApprox(c, 1) {
+		t.Errorf("matrices with different dimensions are approximately equal")
+	}
+
+	b.UpdateValue(4.0001, 1, 1)
+	if a.Equal(b) {
+		t.Errorf("matrices with different values are equal")
+	}
+
+	if !a.EqualApprox(b, 0.001) {
+		t.Errorf("matrices within tolerance are not approximately equal")
+	}
+
+	if a.EqualApprox(b, 0.00001) {
+		t.Errorf("matrices outside of tolerance are approximately equal")
+	}
+}
+
+func TestMatrixFloat64Clone(t *testing.T) {
+	matrix := NewMatrixFloat64(2)
+	matrix.AddRow([]float64{1, 2})
+	matrix.AddRow([]float64{3, 4})
+
+	clone := matrix.Clone()
+	if !clone.Equal(matrix) {
+		t.Errorf("clone is not equal to the original")
+	}
+
+	clone.UpdateValue(10, 0, 0)
+	clone.AddRow([]float64{5, 6})
+
+	v, _ := matrix.GetValue(0, 0)
+	if v != 1 {
+		t.Errorf("original value %v was changed by the clone", v)
+	}
+
+	if matrix.Rows() != 2 {
+		t.Errorf("original rows %d was changed by the clone", matrix.Rows())
+	}
+}
+
+func TestMatrixFloat64SetBackingDataChecked(t *testing.T) {
+	matrix := NewMatrixFloat64(3)
+
+	err := matrix.SetBackingDataChecked(sam.SliceFloat64{1, 2, 3, 4, 5, 6})
+	if err != nil {
+		t.Errorf("set backing data error: %+v", err)
+	}
+
+	if matrix.Rows() != 2 {
+		t.Errorf("rows %d is not 2", matrix.Rows())
+	}
+
+	err = matrix.SetBackingDataChecked(sam.SliceFloat64{1, 2, 3, 4})
+	if err != ErrRowSize {
+		t.Errorf("ErrRowSize was not returned for an invalid length")
+	}
+
+	if matrix.Rows() != 2 {
+		t.Errorf("rows %d was changed by an invalid backing array", matrix.Rows())
+	}
+
+	empty := NewMatrixFloat64(0)
+	err = empty.SetBackingDataChecked(sam.SliceFloat64{1})
+	if err != ErrRowSize {
+		t.Errorf("ErrRowSize was not returned for values in a matrix with no columns")
+	}
+
+	err = empty.SetBackingDataChecked(sam.SliceFloat64{})
+	if err != nil {
+		t.Errorf("set backing data error for an empty array: %+v", err)
+	}
+}
+
+func TestMatrixFloat64Grow(t *testing.T) {
+	matrix := NewMatrixFloat64(3, 2)
+	if cap(matrix.data) != 6 {
+		t.Errorf("capacity %d is not 6", cap(matrix.data))
+	}
+
+	matrix.AddRow([]float64{1, 2, 3})
+	matrix.Grow(10)
+	if cap(matrix.data) < 33 {
+		t.Errorf("capacity %d is less than 33", cap(matrix.data))
+	}
+
+	v, _ := matrix.GetValue(0, 2)
+	if v != 3 || matrix.Rows() != 1 {
+		t.Errorf("grow changed the matrix contents")
+	}
+}
+
+func BenchmarkMatrixFloat64AddRow(b *testing.B) {
+	row := []float64{1, 2, 3, 4, 5, 6, 7, 8}
+	for n := 0; n < b.N; n++ {
+		matrix := NewMatrixFloat64(len(row))
+		for i := 0; i < 10000; i++ {
+			matrix.AddRow(row)
+		}
+	}
+}
+
+func BenchmarkMatrixFloat64AddRowGrow(b *testing.B) {
+	row := []float64{1, 2, 3, 4, 5, 6, 7, 8}
+	for n := 0; n < b.N; n++ {
+		matrix := NewMatrixFloat64(len(row))
+		matrix.Grow(10000)
+		for i := 0; i < 10000; i++ {
+			matrix.AddRow(row)
+		}
+	}
+}
+
+func TestMatrixFloat64AddRows(t *testing.T) {
+	matrix := NewMatrixFloat64(2)
+
+	err := matrix.AddRows([][]float64{{1, 2}, {3, 4}})
+	if err != nil {
+		t.Errorf("add rows error: %+v", err)
+	}
+
+	if matrix.Rows() != 2 {
+		t.Errorf("rows %d is not 2", matrix.Rows())
+	}
+
+	err = matrix.AddRows([][]float64{{5, 6}, {7, 8}, {9}, {10, 11}})
+	if err != ErrRowSize {
+		t.Errorf("ErrRowSize was not returned for a bad row")
+	}
+
+	if matrix.Rows() != 2 {
+		t.Errorf("rows %d is not 2 after a failed insert", matrix.Rows())
+	}
+}
+
+func TestMatrixFloat64Reshape(t *testing.T) {
+	matrix := NewMatrixFloat64(3)
+	for i := 0; i < 4; i++ {
+		matrix.AddRow([]float64{float64(i * 3), float64(i*3 + 1), float64(i*3 + 2)})
+	}
+
+	err := matrix.Reshape(4)
+	if err != nil {
+		t.Errorf("reshape error: %+v", err)
+	}
+
+	r, c := matrix.Dimensions()
+	if r != 3 || c != 4 {
+		t.Errorf("reshaped dimensions (%d, %d) are not (3, 4)", r, c)
+	}
+
+	for row := 0; row < 3; row++ {
+		for column := 0; column < 4; column++ {
+			v, _ := matrix.GetValue(row, column)
+			if v != float64(row*4+column) {
+				t.Errorf("value at (%d, %d) is %v and not %d", row, column, v, row*4+column)
+			}
+		}
+	}
+
+	err = matrix.Reshape(5)
+	if err != ErrDimensionMismatch {
+		t.Errorf("ErrDimensionMismatch was not returned for an invalid column count")
+	}
+
+	if matrix.Columns() != 4 {
+		t.Errorf("columns %d was changed by an invalid reshape", matrix.Columns())
+	}
+}
+
+func TestMatrixFloat64Flatten(t *testing.T) {
+	matrix := NewMatrixFloat64(2)
+	matrix.AddRow([]float64{1, 2})
+	matrix.AddRow([]float64{3, 4})
+
+	flat := matrix.Flatten()
+	if !flat.Equal(sam.SliceFloat64{1, 2, 3, 4}) {
+		t.Errorf("flattened values %v are not [1 2 3 4]", flat)
+	}
+
+	flat[0] = 100
+	v, _ := matrix.GetValue(0, 0)
+	if v != 1 {
+		t.Errorf("matrix value %v was changed through the flattened copy", v)
+	}
+}
+
+func TestMatrixFloat64RowArgmaxArgmin(t *testing.T) {
+	// class probabilities
+	matrix := NewMatrixFloat64(3)
+	matrix.AddRow([]float64{0.7, 0.2, 0.1})
+	matrix.AddRow([]float64{0.1, 0.3, 0.6})
+	matrix.AddRow([]float64{0.4, 0.4, 0.2})
+
+	argmax := matrix.RowArgmax()
+	for i, e := range []int{0, 2, 0} {
+		if argmax[i] != e {
+			t.Errorf("argmax of row %d is %d and not %d", i, argmax[i], e)
+		}
+	}
+
+	argmin := matrix.RowArgmin()
+	for i, e := range []int{2, 0, 2} {
+		if argmin[i] != e {
+			t.Errorf("argmin of row %d is %d and not %d", i, argmin[i], e)
+		}
+	}
+}
+
+func TestMatrixFloat64SoftmaxRows(t *testing.T) {
+	matrix := NewMatrixFloat64(3)
+	matrix.AddRow([]float64{1, 2, 3})
+	matrix.AddRow([]float64{1000, 1001, 999})
+	matrix.AddRow([]float64{-1000, -1001, -999})
+
+	matrix.SoftmaxRows()
+
+	for i := 0; i < matrix.Rows(); i++ {
+		row := matrix.Values(i)
+		sum := sam.SliceFloat64(row).Sum()
+		if math.Abs(sum-1) > 1e-9 {
+			t.Errorf("row %d sums to %v and not 1", i, sum)
+		}
+		for _, v := range row {
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				t.Errorf("row %d contains a non-finite value: %v", i, row)
+			}
+		}
+	}
+
+	first := matrix.Values(0)
+	if !(first[0] < first[1] && first[1] < first[2]) {
+		t.Errorf("row 0 ordering was not preserved: %v", first)
+	}
+
+	second := matrix.Values(1)
+	if !(second[2] < second[0] && second[0] < second[1]) {
+		t.Errorf("row 1 ordering was not preserved: %v", second)
+	}
+}
+
+func TestMatrixFloat64AbsClamp(t *testing.T) {
+	matrix := NewMatrixFloat64(3)
+	matrix.AddRow([]float64{-1, 2, -3})
+
+	matrix.Abs()
+	if !matrix.data.Equal(sam.SliceFloat64{1, 2, 3}) {
+		t.Errorf("absolute values %v are not [1 2 3]", matrix.data)
+	}
+
+	matrix.AddRow([]float64{-5, 0.5, math.NaN()})
+	matrix.Clamp(-1, 2)
+
+	expected := sam.SliceFloat64{1, 2, 2, -1, 0.5}
+	for i, e := range expected {
+		if matrix.data[i] != e {
+			t.Errorf("clamped value %v at index %d is not %v", matrix.data[i], i, e)
+		}
+	}
+
+	if !math.IsNaN(matrix.data[5]) {
+		t.Errorf("NaN was changed to %v by clamping", matrix.data[5])
+	}
+}
+
+func TestMatrixFloat64CountNonFinite(t *testing.T) {
+	matrix := NewMatrixFloat64(3)
+	matrix.AddRow([]float64{math.NaN(), 1, math.Inf(1)})
+	matrix.AddRow([]float64{math.Inf(-1), math.NaN(), math.NaN()})
+	matrix.AddRow([]float64{0, -1, math.MaxFloat64})
+
+	nans, infs := matrix.CountNonFinite()
+	if nans != 3 {
+		t.Errorf("nan count %d is not 3", nans)
+	}
+
+	if infs != 2 {
+		t.Errorf("inf count %d is not 2", infs)
+	}
+}
+
+func TestMatrixFloat64ReplaceNonFinite(t *testing.T) {
+	matrix := NewMatrixFloat64(3)
+	matrix.AddRow([]float64{math.NaN(), 1, math.Inf(1)})
+	matrix.AddRow([]float64{2, math.Inf(-1), 3})
+
+	count := matrix.ReplaceNonFinite(0)
+	if count != 3 {
+		t.Errorf("replaced count %d is not 3", count)
+	}
+
+	if !matrix.data.Equal(sam.SliceFloat64{0, 1, 0, 2, 0, 3}) {
+		t.Errorf("values %v are not [0 1 0 2 0 3]", matrix.data)
+	}
+
+	if matrix.ReplaceNonFinite(0) != 0 {
+		t.Errorf("finite values were replaced")
+	}
+}
+
+func TestOneHot(t *testing.T) {
+	labels := sam.SliceInt{2, 0, 1, 2}
+	matrix, err := OneHot(labels, 3)
+	if err != nil {
+		t.Errorf("one hot error: %+v", err)
+	}
+
+	r, c := matrix.Dimensions()
+	if r != 4 || c != 3 {
+		t.Errorf("one hot dimensions (%d, %d) are not (4, 3)", r, c)
+	}
+
+	decoded := matrix.RowArgmax()
+	for i, label := range labels {
+		if decoded[i] != label {
+			t.Errorf("decoded label %d at row %d is not %d", decoded[i], i, label)
+		}
+	}
+
+	sums := matrix.RowSums()
+	if !sums.Equal(sam.SliceFloat64{1, 1, 1, 1}) {
+		t.Errorf("one hot row sums %v are not all 1", sums)
+	}
+
+	_, err = OneHot(sam.SliceInt{0, 3}, 3)
+	if err != ErrColumnIndex {
+		t.Errorf("ErrColumnIndex was not returned for an out of range label")
+	}
+
+	_, err = OneHot(sam.SliceInt{0}, -1)
+	if err != ErrInvalidArgument {
+		t.Errorf("ErrInvalidArgument was not returned for negative categories")
+	}
+}
+
+func TestMatrixFloat64ApplyDropout(t *testing.T) {
+	matrix := Random(10, 10, rand.New(rand.NewSource(1)))
+	original := matrix.Clone()
+
+	err := matrix.ApplyDropout(0, rand.New(rand.NewSource(2)))
+	if err != nil {
+		t.Errorf("dropout error: %+v", err)
+	}
+
+	if !matrix.Equal(original) {
+		t.Errorf("dropout with rate 0 changed the matrix")
+	}
+
+	a := original.Clone()
+	b := original.Clone()
+	a.ApplyDropout(0.5, rand.New(rand.NewSource(3)))
+	b.ApplyDropout(0.5, rand.New(rand.NewSource(3)))
+
+	if !a.Equal(b) {
+		t.Errorf("dropout with the same seed produced different matrices")
+	}
+
+	var dropped int
+	for i, v := range a.data {
+		if v == 0 {
+			dropped++
+		} else if math.Abs(v-original.data[i]*2) > 1e-12 {
+			t.Errorf("surviving value %v was not scaled from %v", v, original.data[i])
+		}
+	}
+
+	if dropped == 0 || dropped == len(a.data) {
+		t.Errorf("dropout with rate 0.5 dropped %d of %d values", dropped, len(a.data))
+	}
+
+	err = matrix.ApplyDropout(1, nil)
+	if err != ErrInvalidArgument {
+		t.Errorf("ErrInvalidArgument was not returned for a rate of 1")
+	}
+}
+
+func TestMatrixFloat64AppendMatrix(t *testing.T) {
+	matrix := NewMatrixFloat64(2)
+	matrix.AddRow([]float64{1, 2})
+
+	first := NewMatrixFloat64(2)
+	first.AddRows([][]float64{{3, 4}, {5, 6}})
+
+	second := NewMatrixFloat64(2)
+	second.AddRows([][]float64{{7, 8}, {9, 10}, {11, 12}})
+
+	for _, other := range []*MatrixFloat64{first, second} {
+		err := matrix.AppendMatrix(other)
+		if err != nil {
+			t.Errorf("append matrix error: %+v", err)
+		}
+	}
+
+	if matrix.Rows() != 6 {
+		t.Errorf("rows %d is not 6", matrix.Rows())
+	}
+
+	v, _ := matrix.GetValue(5, 1)
+	if v != 12 {
+		t.Errorf("last value %v is not 12", v)
+	}
+
+	err := matrix.AppendMatrix(NewMatrixFloat64(3))
+	if err != ErrDimensionMismatch {
+		t.Errorf("ErrDimensionMismatch was not returned for mismatched columns")
+	}
+}
+
+func TestMatrixFloat64SetColumnData(t *testing.T) {
+	matrix := NewMatrixFloat64(2)
+	matrix.AddRows([][]float64{{1, 10}, {2, 20}, {3, 30}})
+
+	column, err := matrix.GetColumnData(1)
+	if err != nil {
+		t.Errorf("get column data error: %+v", err)
+	}
+
+	if !column.Equal(sam.SliceFloat64{10, 20, 30}) {
+		t.Errorf("column data %v is not [10 20 30]", column)
+	}
+
+	for i := range column {
+		column[i] /= 10
+	}
+
+	err = matrix.SetColumnData(1, column)
+	if err != nil {
+		t.Errorf("set column data error: %+v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		v, _ := matrix.GetValue(i, 1)
+		if v != float64(i+1) {
+			t.Errorf("value at (%d, 1) is %v and not %d", i, v, i+1)
+		}
+		v, _ = matrix.GetValue(i, 0)
+		if v != float64(i+1) {
+			t.Errorf("value at (%d, 0) was changed to %v", i, v)
+		}
+	}
+
+	err = matrix.SetColumnData(1, sam.SliceFloat64{1, 2})
+	if err != ErrDimensionMismatch {
+		t.Errorf("ErrDimensionMismatch was not returned for a short column")
+	}
+
+	err = matrix.SetColumnData(2, column)
+	if err != ErrColumnIndex {
+		t.Errorf("ErrColumnIndex was not returned for an out of bounds column")
+	}
+}
+
+func TestMatrixFloat64Shuffle(t *testing.T) {
+	matrix := NewMatrixFloat64(2)
+	for i := 0; i < 20; i++ {
+		matrix.AddRow([]float64{float64(i), float64(i * i)})
+	}
+
+	a := matrix.Clone()
+	b := matrix.Clone()
+	a.Shuffle(rand.New(rand.NewSource(7)))
+	b.Shuffle(rand.New(rand.NewSource(7)))
+
+	if !a.Equal(b) {
+		t.Errorf("shuffle with the same seed produced different orderings")
+	}
+
+	if a.Equal(matrix) {
+		t.Errorf("shuffle did not change the row order")
+	}
+
+	seen := make(map[float64]bool)
+	for i := 0; i < a.Rows(); i++ {
+		row := a.Values(i)
+		if row[1] != row[0]*row[0] {
+			t.Errorf("row %d was not moved intact: %v", i, row)
+		}
+		seen[row[0]] = true
+	}
+
+	if len(seen) != 20 {
+		t.Errorf("shuffled matrix has %d distinct rows and not 20", len(seen))
+	}
+}
+
+func TestMatrixFloat64KFold(t *testing.T) {
+	matrix := NewMatrixFloat64(1)
+	for i := 0; i < 11; i++ {
+		matrix.AddRow([]float64{float64(i)})
+	}
+
+	folds, err := matrix.KFold(3, rand.New(rand.NewSource(5)))
+	if err != nil {
+		t.Errorf("kfold error: %+v", err)
+	}
+
+	if len(folds) != 3 {
+		t.Errorf("%d folds were returned and not 3", len(folds))
+	}
+
+	seen := make(map[int]bool)
+	for i, fold := range folds {
+		if len(fold) < 3 || len(fold) > 4 {
+			t.Errorf("fold %d has %d rows", i, len(fold))
+		}
+		for _, row := range fold {
+			if seen[row] {
+				t.Errorf("row %d appears in more than one fold", row)
+			}
+			seen[row] = true
+		}
+	}
+
+	if len(seen) != matrix.Rows() {
+		t.Errorf("folds cover %d rows and not %d", len(seen), matrix.Rows())
+	}
+
+	_, err = matrix.KFold(1, nil)
+	if err != ErrInvalidArgument {
+		t.Errorf("ErrInvalidArgument was not returned for k of 1")
+	}
+
+	_, err = matrix.KFold(12, nil)
+	if err != ErrInvalidArgument {
+		t.Errorf("ErrInvalidArgument was not returned for k greater than the rows")
+	}
+}
+
+func TestMatrixFloat64Map(t *testing.T) {
+	matrix := NewMatrixFloat64(3)
+	matrix.AddRow([]float64{1, 2, 3})
+	matrix.AddRow([]float64{-1, -2, -3})
+
+	squared := matrix.Map(func(input interface{}) interface{} {
+		v := input.(float64)
+		return v * v
+	})
+
+	if !squared.data.Equal(sam.SliceFloat64{1, 4, 9, 1, 4, 9}) {
+		t.Errorf("mapped values %v are not squares", squared.data)
+	}
+
+	if !matrix.data.Equal(sam.SliceFloat64{1, 2, 3, -1, -2, -3}) {
+		t.Errorf("original values %v were changed by map", matrix.data)
+	}
+}
+
+func TestMatrixFloat64WeightedSample(t *testing.T) {
+	matrix := NewMatrixFloat64(1)
+	matrix.AddRows([][]float64{{0}, {1}, {2}, {3}})
+
+	weights := sam.SliceFloat64{1, 0, 8, 1}
+	sample, err := matrix.WeightedSample(10000, weights, rand.New(rand.NewSource(11)))
+	if err != nil {
+		t.Errorf("weighted sample error: %+v", err)
+	}
+
+	if sample.Rows() != 10000 {
+		t.Errorf("sampled %d rows and not 10000", sample.Rows())
+	}
+
+	counts := make([]int, 4)
+	for _, v := range sample.data {
+		counts[int(v)]++
+	}
+
+	if counts[1] != 0 {
+		t.Errorf("row with zero weight was sampled %d times", counts[1])
+	}
+
+	if counts[2] < 7500 || counts[2] > 8500 {
+		t.Errorf("heavily weighted row was sampled %d times out of 10000", counts[2])
+	}
+
+	if counts[2] <= counts[0]*4 || counts[2] <= counts[3]*4 {
+		t.Errorf("heavily weighted row was not sampled more often: %v", counts)
+	}
+
+	_, err = matrix.WeightedSample(1, sam.SliceFloat64{1, 1}, nil)
+	if err != ErrDimensionMismatch {
+		t.Errorf("ErrDimensionMismatch was not returned for mismatched weights")
+	}
+
+	_, err = matrix.WeightedSample(1, sam.SliceFloat64{0, 0, 0, 0}, nil)
+	if err != ErrInvalidArgument {
+		t.Errorf("ErrInvalidArgument was not returned for zero weights")
+	}
+
+	for _, weights := range []sam.SliceFloat64{
+		{math.NaN(), 1, 1, 1},
+		{1, math.Inf(1), 1, 1},
+		{math.MaxFloat64, math.MaxFloat64, 1, 1},
+	} {
+		_, err = matrix.WeightedSample(1, weights, nil)
+		if err != ErrInvalidArgument {
+			t.Errorf("ErrInvalidArgument was not returned for weights %v", weights)
+		}
+	}
+}
+
+func TestMatrixFloat64FlatMapRows(t *testing.T) {
+	matrix := NewMatrixFloat64(2)
+	matrix.AddRows([][]float64{{1, 1}, {2, 2}, {3, 3}, {4, 4}})
+
+	// drop odd rows and duplicate even rows
+	result, err := matrix.FlatMapRows(func(row sam.SliceFloat64) [][]float64 {
+		if int(row[0])%2 == 1 {
+			return nil
+		}
+		return [][]float64{row, {row[0] * 10, row[1] * 10}}
+	})
+	if err != nil {
+		t.Errorf("flat map rows error: %+v", err)
+	}
+
+	expected := sam.SliceFloat64{2, 20, 4, 40}
+	if result.Rows() != len(expected) {
+		t.Errorf("result has %d rows and not %d", result.Rows(), len(expected))
+	}
+
+	for i, e := range expected {
+		v, _ := result.GetValue(i, 1)
+		if v != e {
+			t.Errorf("row %d is %v and not %v", i, v, e)
+		}
+	}
+
+	_, err = matrix.FlatMapRows(func(row sam.SliceFloat64) [][]float64 {
+		return [][]float64{{row[0]}}
+	})
+	if err != ErrRowSize {
+		t.Errorf("ErrRowSize was not returned for a short row")
+	}
+}
+
+func TestMatrixFloat64Cap(t *testing.T) {
+	matrix := NewMatrixFloat64(2, 2)
+	if matrix.Cap() != 2 {
+		t.Errorf("capacity %d is not 2", matrix.Cap())
+	}
+
+	matrix.AddRows([][]float64{{1, 2}, {3, 4}})
+	if matrix.Cap() != 2 || matrix.Len() != 2 {
+		t.Errorf("capacity %d and length %d are not 2", matrix.Cap(), matrix.Len())
+	}
+
+	// the backing array is full so this reallocates
+	matrix.AddRow([]float64{5, 6})
+	if matrix.Cap() <= 2 {
+		t.Errorf("capacity %d did not grow after reallocating", matrix.Cap())
+	}
+
+	matrix.Grow(100)
+	if matrix.Cap() < 103 {
+		t.Errorf("capacity %d is less than 103 after growing", matrix.Cap())
+	}
+
+	empty, _ := FromRows(nil)
+	if empty.Cap() != 0 {
+		t.Errorf("capacity %d of a matrix with no columns is not 0", empty.Cap())
+	}
+}
+
+func TestMatrixFloat64ParallelRowSums(t *testing.T) {
+	matrix := Random(101, 7, rand.New(rand.NewSource(3)))
+
+	serial := matrix.RowSums()
+	for _, workers := range []int{0, 1, 4, 200} {
+		parallel := matrix.ParallelRowSums(workers)
+		if !parallel.Equal(serial) {
+			t.Errorf("parallel row sums with %d workers do not match the serial sums", workers)
+		}
+	}
+
+	if len(NewMatrixFloat64(3).ParallelRowSums(4)) != 0 {
+		t.Errorf("parallel row sums of an empty matrix are not empty")
+	}
+}
+
+func BenchmarkMatrixFloat64RowSums(b *testing.B) {
+	matrix := Random(1000, 1000, rand.New(rand.NewSource(1)))
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		matrix.RowSums()
+	}
+}
+
+func BenchmarkMatrixFloat64ParallelRowSums(b *testing.B) {
+	matrix := Random(1000, 1000, rand.New(rand.NewSource(1)))
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		matrix.ParallelRowSums(4)
+	}
+}
+
+func TestMatrixFloat64AppendColumn(t *testing.T) {
+	matrix := NewMatrixFloat64(2)
+	rows := [][]float64{{1, 2}, {3, 4}, {5, 6}}
+	matrix.AddRows(rows)
+
+	matrix.AppendColumn(-1)
+
+	if r, c := matrix.Dimensions(); r != 3 || c != 3 {
+		t.Errorf("dimensions %dx%d are not 3x3", r, c)
+	}
+
+	for i, row := range rows {
+		for j, v := range append(row, -1) {
+			if value, _ := matrix.GetValue(i, j); value != v {
+				t.Errorf("value %v at (%d, %d) is not %v", value, i, j, v)
+			}
+		}
+	}
+}
+
+func TestMatrixFloat64InsertColumn(t *testing.T) {
+	matrix := NewMatrixFloat64(3)
+	matrix.AddRows([][]float64{{1, 2, 3}, {4, 5, 6}})
+
+	err := matrix.InsertColumn(1, 9)
+	if err != nil {
+		t.Errorf("insert column error: %+v", err)
+	}
+
+	expected := [][]float64{{1, 9, 2, 3}, {4, 9, 5, 6}}
+	if matrix.Columns() != 4 {
+		t.Errorf("columns is %d and not 4", matrix.Columns())
+	}
+	for i, row := range expected {
+		for j, v := range row {
+			if value, _ := matrix.GetValue(i, j); value != v {
+				t.Errorf("value %v at (%d, %d) is not %v", value, i, j, v)
+			}
+		}
+	}
+
+	matrix.InsertColumn(4, 0)
+	if v, _ := matrix.GetValue(1, 4); v != 0 {
+		t.Errorf("value %v of the appended column is not 0", v)
+	}
+
+	err = matrix.InsertColumn(6, 0)
+	if err != ErrColumnIndex {
+		t.Errorf("ErrColumnIndex was not returned for an out of bounds index")
+	}
+}
+
+func TestMatrixFloat64Each(t *testing.T) {
+	matrix := NewMatrixFloat64(2)
+	matrix.AddRows([][]float64{{1, 2}, {3, 4}, {5, 6}})
+
+	var sum float64
+	var indices []int
+	err := matrix.Each(func(i int, row sam.SliceFloat64) error {
+		sum += row.Sum()
+		indices = append(indices, i)
+		return nil
+	})
+	if err != nil {
+		t.Errorf("each error: %+v", err)
+	}
+
+	if sum != 21 {
+		t.Errorf("sum %v is not 21", sum)
+	}
+
+	if len(indices) != 3 || indices[2] != 2 {
+		t.Errorf("indices %v are not [0 1 2]", indices)
+	}
+
+	stop := errors.New("stop")
+	visited := 0
+	err = matrix.Each(func(i int, row sam.SliceFloat64) error {
+		visited++
+		if i == 1 {
+			return stop
+		}
+		return nil
+	})
+	if err != stop {
+		t.Errorf("error %v is not the error returned by the function", err)
+	}
+
+	if visited != 2 {
+		t.Errorf("visited rows %d is not 2", visited)
+	}
+}
+
+func TestMatrixFloat64ToRowsFromRows(t *testing.T) {
+	rows := [][]float64{{1, 2, 3}, {4, 5, 6}}
+	matrix, err := FromRows(rows)
+	if err != nil {
+		t.Errorf("from rows error: %+v", err)
+	}
+
+	if r, c := matrix.Dimensions(); r != 2 || c != 3 {
+		t.Errorf("dimensions %dx%d are not 2x3", r, c)
+	}
+
+	// the matrix does not share the arrays
+	rows[0][0] = 9
+	if v, _ := matrix.GetValue(0, 0); v != 1 {
+		t.Errorf("value %v at (0, 0) is not 1", v)
+	}
+
+	copied := matrix.ToRows()
+	copied[1][2] = 9
+	if v, _ := matrix.GetValue(1, 2); v != 6 {
+		t.Errorf("value %v at (1, 2) is not 6", v)
+	}
+
+	roundTrip, _ := FromRows(matrix.ToRows())
+	if !roundTrip.Equal(matrix) {
+		t.Errorf("round trip matrix %v is not %v", roundTrip.data, matrix.data)
+	}
+
+	empty, err := FromRows([][]float64{})
+	if err != nil {
+		t.Errorf("from rows error for no rows: %+v", err)
+	}
+
+	if empty.Rows() != 0 || len(empty.ToRows()) != 0 {
+		t.Errorf("matrix from no rows is not empty")
+	}
+
+	if len(NewMatrixFloat64(3).ToRows()) != 0 {
+		t.Errorf("rows of an empty matrix are not empty")
+	}
+
+	_, err = FromRows([][]float64{{1, 2}, {3}})
+	if err != ErrRowSize {
+		t.Errorf("ErrRowSize was not returned for jagged rows")
+	}
+
+	_, err = FromRows([][]float64{{}})
+	if err != ErrRowSize {
+		t.Errorf("ErrRowSize was not returned for an empty row")
+	}
+}
+
+func TestMatrixFloat64GetValueRel(t *testing.T) {
+	matrix := NewMatrixFloat64(3)
+	matrix.AddRows([][]float64{{1, 2, 3}, {4, 5, 6}})
+
+	cases := []struct {
+		row, column int
+		value       float64
+	}{
+		{-1, 0, 4},
+		{0, -1, 3},
+		{-1, -1, 6},
+		{-2, -3, 1},
+		{1, 1, 5},
+	}
+	for _, c := range cases {
+		v, err := matrix.GetValueRel(c.row, c.column)
+		if err != nil {
+			t.Errorf("get value rel error at (%d, %d): %+v", c.row, c.column, err)
+		}
+		if v != c.value {
+			t.Errorf("value %v at (%d, %d) is not %v", v, c.row, c.column, c.value)
+		}
+	}
+
+	_, err := matrix.GetValueRel(-3, 0)
+	indexErr, ok := err.(*IndexError)
+	if !ok || indexErr.Kind != RowKind || indexErr.Index != -3 {
+		t.Errorf("row IndexError for -3 was not returned: %v", err)
+	}
+
+	_, err = matrix.GetValueRel(0, -4)
+	indexErr, ok = err.(*IndexError)
+	if !ok || indexErr.Kind != ColumnKind || indexErr.Index != -4 {
+		t.Errorf("column IndexError for -4 was not returned: %v", err)
+	}
+
+	_, err = matrix.GetValueRel(2, 0)
+	if !errors.Is(err, ErrRowIndex) {
+		t.Errorf("ErrRowIndex was not returned for an out of bounds row")
+	}
+}
+
+func TestMatrixFloat64AddSubtractRowVector(t *testing.T) {
+	matrix := NewMatrixFloat64(3)
+	rows := [][]float64{{1, 2, 3}, {4, 5, 6}}
+	matrix.AddRows(rows)
+
+	bias := sam.SliceFloat64{10, -1, 0.5}
+	err := matrix.AddRowVector(bias)
+	if err != nil {
+		t.Errorf("add row vector error: %+v", err)
+	}
+
+	for i, row := range rows {
+		for j, v := range row {
+			if value, _ := matrix.GetValue(i, j); value != v+bias[j] {
+				t.Errorf("value %v at (%d, %d) is not %v", value, i, j, v+bias[j])
+			}
+		}
+	}
+
+	err = matrix.SubtractRowVector(bias)
+	if err != nil {
+		t.Errorf("subtract row vector error: %+v", err)
+	}
+
+	original, _ := FromRows(rows)
+	if !matrix.Equal(original) {
+		t.Errorf("matrix %v is not %v after subtracting the vector", matrix.data, original.data)
+	}
+
+	err = matrix.AddRowVector(sam.SliceFloat64{1, 2})
+	if err != ErrRowSize {
+		t.Errorf("ErrRowSize was not returned for a short vector")
+	}
+
+	err = matrix.SubtractRowVector(sam.SliceFloat64{1, 2, 3, 4})
+	if err != ErrRowSize {
+		t.Errorf("ErrRowSize was not returned for a long vector")
+	}
+}
+
+func TestMatrixFloat64RemoveRow(t *testing.T) {
+	matrix := NewMatrixFloat64(2)
+	matrix.AddRows([][]float64{{1, 2}, {3, 4}, {5, 6}})
+
+	err := matrix.RemoveRow(1)
+	if err != nil {
+		t.Errorf("remove row error: %+v", err)
+	}
+
+	expected, _ := FromRows([][]float64{{1, 2}, {5, 6}})
+	if !matrix.Equal(expected) {
+		t.Errorf("matrix %v is not %v after removing a row", matrix.data, expected.data)
+	}
+
+	for _, row := range []int{-1, 2} {
+		err = matrix.RemoveRow(row)
+		indexErr, ok := err.(*IndexError)
+		if !ok || indexErr.Kind != RowKind || indexErr.Index != row || indexErr.Bound != 2 {
+			t.Errorf("row IndexError was not returned for row %d: %v", row, err)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+import (
+	"github.com/humilityai/sam"
+)
+
+// MatrixInt is backed by a single array of int64 values.
+type MatrixInt struct {
+	data    sam.SliceInt64
+	columns int
+}
+
+// NewMatrixInt creates a Matrix with the specified column
+// count.
+func NewMatrixInt(columns int) *MatrixInt {
+	return &MatrixInt{
+		data:    make(sam.SliceInt64, 0),
+		columns: columns,
+	}
+}
+
+// AddRow will append the int64 array to the matrix as a new row.
+// If the size of the row does not match the number of columns
+// in the matrix then an ErrRowSize will be returned.
+func (m *MatrixInt) AddRow(row sam.SliceInt64) error {
+	if len(row) != m.columns {
+		return ErrRowSize
+	}
+
+	m.data = append(m.data, row...)
+
+	return nil
+}
+
+// Columns will return the number of columns found
+// in the matrix.
+func (m *MatrixInt) Columns() int {
+	return m.columns
+}
+
+// Dimensions returns the number of rows and columns
+// in the matrix: (rows, columns).
+func (m *MatrixInt) Dimensions() (int, int) {
+	return m.Rows(), m.columns
+}
+
+// GetColumnData will return a int64 array that contains all the data points
+// of the specified column.
+// If the specified column is out of bounds then an ErrColumnIndex will be returned.
+func (m *MatrixInt) GetColumnData(column int) (data sam.SliceInt64, err error) {
+	if column < 0 || column >= m.columns {
+		return data, ErrColumnIndex
+	}
+
+	for i := column; i < len(m.data); i += m.columns {
+		data = append(data, m.data[i])
+	}
+
+	return
+}
+
+// GetRow ...
+func (m *MatrixInt) GetRow(row int) (sam.Slice, error) {
+	err := m.checkRowAndColumnBounds(row, 0)
+	if err != nil {
+		return sam.SliceInt64{}, err
+	}
+	start := row * m.columns
+
+	return sam.SliceInt64(m.data[start : start+m.columns]), nil
+}
+
+// GetValue will return the int64 value found at the row and column
+// arguments provided. It will return an error if something is
+// invalid about either the row or column argument.
+func (m *MatrixInt) GetValue(row, column int) (int64, error) {
+	err := m.checkRowAndColumnBounds(row, column)
+	if err != nil {
+		return 0, err
+	}
+
+	return m.data[row*m.columns+column], nil
+}
+
+// Iterator will return an object that allows row
+// iteration of the matrix.
+func (m *MatrixInt) Iterator() *Iterator {
+	return &Iterator{
+		Matrix: m,
+		row:    -1,
+	}
+}
+
+// Len is a standard method that satisfies
+// many common interfaces.
+func (m *MatrixInt) Len() int {
+	return m.Rows()
+}
+
+// Rows will return the number of rows found
+// in the matrix. A matrix with no columns
+// has no rows.
+func (m *MatrixInt) Rows() int {
+	if m.columns == 0 {
+		return 0
+	}
+
+	return len(m.data) / m.columns
+}
+
+// Type is the type of values in MatrixInt
+func (m *MatrixInt) Type() string {
+	return sam.Int64Type
+}
+
+// UpdateValue will update the value found at the provided row and column
+// arguments.
+// If the row or column are out of bounds for the matrix then the proper
+// error will be returned.
+func (m *MatrixInt) UpdateValue(value int64, row, column int) error {
+	err := m.checkRowAndColumnBounds(row, column)
+	if err != nil {
+		return err
+	}
+
+	m.data[row*m.columns+column] = value
+
+	return nil
+}
+
+func (m *MatrixInt) checkRowAndColumnBounds(row, column int) error {
+	rows := m.Rows()
+	if row >= rows || row < 0 {
+		return &IndexError{Kind: RowKind, Index: row, Bound: rows}
+	} else if column < 0 || column >= m.columns {
+		return &IndexError{Kind: ColumnKind, Index: column, Bound: m.columns}
+	}
+
+	return nil
+}
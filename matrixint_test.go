@@ -0,0 +1,107 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/humilityai/sam"
+)
+
+func TestMatrixInt(t *testing.T) {
+	columns := 2
+	matrix := NewMatrixInt(columns)
+
+	if matrix.Columns() != columns {
+		t.Errorf("matrix columns %d does not match columns argument %d", matrix.Columns(), columns)
+	}
+
+	if matrix.Rows() != 0 {
+		t.Errorf("rows is not 0")
+	}
+
+	err := matrix.AddRow([]int64{1, 2})
+	if err != nil {
+		t.Errorf("matrix row add error: %+v", err)
+	}
+	matrix.AddRow([]int64{3, 4})
+
+	if r, c := matrix.Dimensions(); r != 2 || c != columns || matrix.Len() != 2 {
+		t.Errorf("dimensions %dx%d are not 2x%d", r, c, columns)
+	}
+
+	err = matrix.AddRow([]int64{5})
+	if err != ErrRowSize {
+		t.Errorf("matrix ErrRowSize was not caught")
+	}
+
+	if matrix.Type() != sam.Int64Type {
+		t.Errorf("matrix type %v is not %v", matrix.Type(), sam.Int64Type)
+	}
+
+	err = matrix.UpdateValue(9, 1, 0)
+	if err != nil {
+		t.Errorf("update value error: %+v", err)
+	}
+
+	if v, _ := matrix.GetValue(1, 0); v != 9 {
+		t.Errorf("value %d at (1, 0) is not 9", v)
+	}
+
+	row, err := matrix.GetRow(1)
+	if err != nil || !row.Equal(sam.SliceInt64{9, 4}) {
+		t.Errorf("row %v is not [9 4]", row)
+	}
+
+	data, err := matrix.GetColumnData(1)
+	if err != nil || !data.Equal(sam.SliceInt64{2, 4}) {
+		t.Errorf("column data %v is not [2 4]", data)
+	}
+
+	rows := 0
+	iter := matrix.Iterator()
+	for iter.Next() {
+		rows++
+	}
+	if rows != 2 {
+		t.Errorf("iterator visited %d rows and not 2", rows)
+	}
+
+	_, err = matrix.GetValue(2, 0)
+	if !errors.Is(err, ErrRowIndex) {
+		t.Errorf("ErrRowIndex was not returned for an out of bounds row")
+	}
+
+	err = matrix.UpdateValue(7, 0, 2)
+	if !errors.Is(err, ErrColumnIndex) {
+		t.Errorf("ErrColumnIndex was not returned for an out of bounds column")
+	}
+
+	_, err = matrix.GetColumnData(-1)
+	if err != ErrColumnIndex {
+		t.Errorf("ErrColumnIndex was not returned for a negative column")
+	}
+
+	empty := NewMatrixInt(0)
+	if empty.Rows() != 0 {
+		t.Errorf("rows of a matrix with no columns is not 0")
+	}
+
+	_, err = empty.GetValue(0, 0)
+	if !errors.Is(err, ErrRowIndex) {
+		t.Errorf("ErrRowIndex was not returned for a matrix with no columns")
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+import (
+	"github.com/humilityai/sam"
+)
+
+// MatrixString is backed by a single array.
+type MatrixString struct {
+	data    sam.SliceString
+	columns int
+}
+
+// NewMatrixString creates a Matrix with the specified column
+// count.
+func NewMatrixString(columns int) *MatrixString {
+	return &MatrixString{
+		data:    make(sam.SliceString, 0),
+		columns: columns,
+	}
+}
+
+// AddRow will append the string array to the matrix as a new row.
+// If the size of the row does not match the number of columns
+// in the matrix then an ErrRowSize will be returned.
+func (m *MatrixString) AddRow(row sam.SliceString) error {
+	if len(row) != m.columns {
+		return ErrRowSize
+	}
+
+	m.data = append(m.data, row...)
+
+	return nil
+}
+
+// Columns will return the number of columns found
+// in the matrix.
+func (m *MatrixString) Columns() int {
+	return m.columns
+}
+
+// Dimensions returns the number of rows and columns
+// in the matrix: (rows, columns).
+func (m *MatrixString) Dimensions() (int, int) {
+	return m.Rows(), m.columns
+}
+
+// Encode will replace each distinct string in the column with an
+// integer code and return the codes as a single column MatrixInt along
+// with the mapping from string to code so that it can be reused on new
+// data. Codes are assigned from 0 in the order that the strings are
+// first seen.
+// If the specified column is out of bounds then an ErrColumnIndex will be returned.
+func (m *MatrixString) Encode(column int) (*MatrixInt, map[string]int, error) {
+	values, err := m.GetColumnData(column)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	codes := make(map[string]int)
+	matrix := &MatrixInt{
+		data:    make(sam.SliceInt64, len(values)),
+		columns: 1,
+	}
+	for i, value := range values {
+		code, ok := codes[value]
+		if !ok {
+			code = len(codes)
+			codes[value] = code
+		}
+		matrix.data[i] = int64(code)
+	}
+
+	return matrix, codes, nil
+}
+
+// GetColumnData will return a string array that contains all the data points
+// of the specified column.
+// If the specified column is out of bounds then an ErrColumnIndex will be returned.
+func (m *MatrixString) GetColumnData(column int) (data sam.SliceString, err error) {
+	if column < 0 || column >= m.columns {
+		return data, ErrColumnIndex
+	}
+
+	for i := column; i < len(m.data); i += m.columns {
+		data = append(data, m.data[i])
+	}
+
+	return
+}
+
+// GetRow ...
+func (m *MatrixString) GetRow(row int) (sam.Slice, error) {
+	err := m.checkRowAndColumnBounds(row, 0)
+	if err != nil {
+		return sam.SliceString{}, err
+	}
+	start := row * m.columns
+
+	return sam.SliceString(m.data[start : start+m.columns]), nil
+}
+
+// GetValue will return the string value found at the row and column
+// arguments provided. It will return an error if something is
+// invalid about either the row or column argument.
+func (m *MatrixString) GetValue(row, column int) (string, error) {
+	err := m.checkRowAndColumnBounds(row, column)
+	if err != nil {
+		return "", err
+	}
+
+	return m.data[row*m.columns+column], nil
+}
+
+// Iterator will return an object that allows row
+// iteration of the matrix.
+func (m *MatrixString) Iterator() *Iterator {
+	return &Iterator{
+		Matrix: m,
+		row:    -1,
+	}
+}
+
+// Len is a standard method that satisfies
+// many common interfaces.
+func (m *MatrixString) Len() int {
+	return m.Rows()
+}
+
+// Rows will return the number of rows found
+// in the matrix. A matrix with no columns
+// has no rows.
+func (m *MatrixString) Rows() int {
+	if m.columns == 0 {
+		return 0
+	}
+
+	return len(m.data) / m.columns
+}
+
+// Type is the type of values in MatrixString
+func (m *MatrixString) Type() string {
+	return sam.StringType
+}
+
+// UpdateValue will update the value found at the provided row and column
+// arguments.
+// If the row or column are out of bounds for the matrix then the proper
+// error will be returned.
+func (m *MatrixString) UpdateValue(value string, row, column int) error {
+	err := m.checkRowAndColumnBounds(row, column)
+	if err != nil {
+		return err
+	}
+
+	m.data[row*m.columns+column] = value
+
+	return nil
+}
+
+func (m *MatrixString) checkRowAndColumnBounds(row, column int) error {
+	rows := m.Rows()
+	if row >= rows || row < 0 {
+		return &IndexError{Kind: RowKind, Index: row, Bound: rows}
+	} else if column < 0 || column >= m.columns {
+		return &IndexError{Kind: ColumnKind, Index: column, Bound: m.columns}
+	}
+
+	return nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/humilityai/sam"
+)
+
+func TestMatrixString(t *testing.T) {
+	columns := 2
+	matrix := NewMatrixString(columns)
+
+	if matrix.Columns() != columns {
+		t.Errorf("matrix columns %d does not match columns argument %d", matrix.Columns(), columns)
+	}
+
+	if matrix.Rows() != 0 {
+		t.Errorf("rows is not 0")
+	}
+
+	err := matrix.AddRow([]string{"a", "b"})
+	if err != nil {
+		t.Errorf("matrix row add error: %+v", err)
+	}
+	matrix.AddRow([]string{"c", "d"})
+
+	if r, c := matrix.Dimensions(); r != 2 || c != columns || matrix.Len() != 2 {
+		t.Errorf("dimensions %dx%d are not 2x%d", r, c, columns)
+	}
+
+	err = matrix.AddRow([]string{"e"})
+	if err != ErrRowSize {
+		t.Errorf("matrix ErrRowSize was not caught")
+	}
+
+	if matrix.Type() != sam.StringType {
+		t.Errorf("matrix type %v is not %v", matrix.Type(), sam.StringType)
+	}
+
+	err = matrix.UpdateValue("x", 1, 0)
+	if err != nil {
+		t.Errorf("update value error: %+v", err)
+	}
+
+	if v, _ := matrix.GetValue(1, 0); v != "x" {
+		t.Errorf("value %q at (1, 0) is not %q", v, "x")
+	}
+
+	row, err := matrix.GetRow(1)
+	if err != nil || !row.Equal(sam.SliceString{"x", "d"}) {
+		t.Errorf("row %v is not [x d]", row)
+	}
+
+	data, err := matrix.GetColumnData(1)
+	if err != nil || !data.Equal(sam.SliceString{"b", "d"}) {
+		t.Errorf("column data %v is not [b d]", data)
+	}
+
+	rows := 0
+	iter := matrix.Iterator()
+	for iter.Next() {
+		rows++
+	}
+	if rows != 2 {
+		t.Errorf("iterator visited %d rows and not 2", rows)
+	}
+
+	_, err = matrix.GetValue(2, 0)
+	if !errors.Is(err, ErrRowIndex) {
+		t.Errorf("ErrRowIndex was not returned for an out of bounds row")
+	}
+
+	err = matrix.UpdateValue("y", 0, 2)
+	if !errors.Is(err, ErrColumnIndex) {
+		t.Errorf("ErrColumnIndex was not returned for an out of bounds column")
+	}
+
+	_, err = matrix.GetColumnData(-1)
+	if err != ErrColumnIndex {
+		t.Errorf("ErrColumnIndex was not returned for a negative column")
+	}
+
+	empty := NewMatrixString(0)
+	if empty.Rows() != 0 {
+		t.Errorf("rows of a matrix with no columns is not 0")
+	}
+
+	_, err = empty.GetValue(0, 0)
+	if !errors.Is(err, ErrRowIndex) {
+		t.Errorf("ErrRowIndex was not returned for a matrix with no columns")
+	}
+}
+
+func TestMatrixStringEncode(t *testing.T) {
+	matrix := NewMatrixString(2)
+	matrix.AddRow([]string{"red", "a"})
+	matrix.AddRow([]string{"blue", "b"})
+	matrix.AddRow([]string{"red", "c"})
+	matrix.AddRow([]string{"green", "d"})
+	matrix.AddRow([]string{"blue", "e"})
+
+	codes, mapping, err := matrix.Encode(0)
+	if err != nil {
+		t.Errorf("encode error: %+v", err)
+	}
+
+	expected := []int64{0, 1, 0, 2, 1}
+	for i, v := range expected {
+		code, _ := codes.GetValue(i, 0)
+		if code != v {
+			t.Errorf("code %d at row %d is not %d", code, i, v)
+		}
+	}
+
+	for value, code := range map[string]int{"red": 0, "blue": 1, "green": 2} {
+		if mapping[value] != code {
+			t.Errorf("mapping %d for %q is not %d", mapping[value], value, code)
+		}
+	}
+
+	if len(mapping) != 3 {
+		t.Errorf("mapping size %d is not 3", len(mapping))
+	}
+
+	_, _, err = matrix.Encode(2)
+	if err != ErrColumnIndex {
+		t.Errorf("ErrColumnIndex was not returned for an out of bounds column")
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+// Close will release the memory-mapped region backing a matrix
+// created by OpenMmap. The matrix is empty once it has been closed.
+// Calling Close on any other matrix has no effect.
+func (m *MatrixFloat64) Close() error {
+	if m.mapped == nil {
+		return nil
+	}
+
+	err := munmap(m.mapped)
+	if err != nil {
+		return err
+	}
+
+	m.mapped = nil
+	m.data = nil
+
+	return nil
+}
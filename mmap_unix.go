@@ -0,0 +1,90 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package matrix
+
+import (
+	"os"
+	"reflect"
+	"syscall"
+	"unsafe"
+
+	"github.com/humilityai/sam"
+)
+
+// OpenMmap will memory-map a file that was written by WriteBinary
+// and return a matrix whose values are read directly from the mapped
+// region without being copied into memory.
+// The mapping is shared with the file, so UpdateValue and any other
+// method that modifies values in place writes through to the file.
+// Methods that add values, such as AddRow, copy the values out of the
+// mapped region first and do not change the file.
+// Close must be called to release the mapping.
+func OpenMmap(path string) (*MatrixFloat64, error) {
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	size := info.Size()
+	if size < binaryHeaderSize {
+		return nil, ErrTruncated
+	}
+
+	mapped, err := syscall.Mmap(int(f.Fd()), 0, int(size), syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, err
+	}
+
+	columns, rows, err := parseBinaryHeader(mapped[:binaryHeaderSize], binaryMagic)
+	if err != nil {
+		syscall.Munmap(mapped)
+		return nil, err
+	}
+
+	// the size in bytes of the values can overflow, so
+	// compare counts of values instead
+	n := rows * columns
+	if int64(n) > (size-binaryHeaderSize)/8 {
+		syscall.Munmap(mapped)
+		return nil, ErrTruncated
+	}
+
+	var data sam.SliceFloat64
+	if n > 0 {
+		header := (*reflect.SliceHeader)(unsafe.Pointer(&data))
+		header.Data = uintptr(unsafe.Pointer(&mapped[binaryHeaderSize]))
+		header.Len = n
+		header.Cap = n
+	}
+
+	return &MatrixFloat64{
+		data:    data,
+		columns: columns,
+		mapped:  mapped,
+	}, nil
+}
+
+func munmap(b []byte) error {
+	return syscall.Munmap(b)
+}
@@ -0,0 +1,123 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package matrix
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOpenMmap(t *testing.T) {
+	dir, err := ioutil.TempDir("", "matrix")
+	if err != nil {
+		t.Fatalf("temp dir error: %+v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	matrix := NewMatrixFloat64(2)
+	matrix.AddRows([][]float64{{1, 2}, {3, 4}, {5, 6}})
+
+	path := filepath.Join(dir, "matrix.bin")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("create error: %+v", err)
+	}
+
+	err = matrix.WriteBinary(f)
+	if err != nil {
+		t.Errorf("write binary error: %+v", err)
+	}
+	f.Close()
+
+	mapped, err := OpenMmap(path)
+	if err != nil {
+		t.Fatalf("open mmap error: %+v", err)
+	}
+
+	if !mapped.Equal(matrix) {
+		t.Errorf("mapped matrix %v is not %v", mapped.data, matrix.data)
+	}
+
+	// updates write through to the file
+	err = mapped.UpdateValue(40, 1, 1)
+	if err != nil {
+		t.Errorf("update value error: %+v", err)
+	}
+
+	err = mapped.Close()
+	if err != nil {
+		t.Errorf("close error: %+v", err)
+	}
+
+	if mapped.mapped != nil || mapped.Rows() != 0 {
+		t.Errorf("close did not release the mapping")
+	}
+
+	err = mapped.Close()
+	if err != nil {
+		t.Errorf("second close error: %+v", err)
+	}
+
+	f, err = os.Open(path)
+	if err != nil {
+		t.Fatalf("open error: %+v", err)
+	}
+	defer f.Close()
+
+	reread, err := ReadBinary(f)
+	if err != nil {
+		t.Errorf("read binary error: %+v", err)
+	}
+
+	v, _ := reread.GetValue(1, 1)
+	if v != 40 {
+		t.Errorf("updated value %v was not written through to the file", v)
+	}
+
+	// a header with an oversized column count
+	header := matrix.binaryHeader(binaryMagic)
+	binary.LittleEndian.PutUint64(header[8:], 1<<61)
+	corrupt := filepath.Join(dir, "corrupt.bin")
+	err = ioutil.WriteFile(corrupt, header, 0644)
+	if err != nil {
+		t.Fatalf("write file error: %+v", err)
+	}
+
+	_, err = OpenMmap(corrupt)
+	if err != ErrInvalidFormat {
+		t.Errorf("ErrInvalidFormat was not returned for an oversized column count: %v", err)
+	}
+
+	// a row count whose size in bytes overflows when added to the
+	// header, in a file holding a single value
+	header = matrix.binaryHeader(binaryMagic)
+	binary.LittleEndian.PutUint64(header[8:], 1)
+	binary.LittleEndian.PutUint64(header[16:], 1<<60-1)
+	err = ioutil.WriteFile(corrupt, append(header, make([]byte, 8)...), 0644)
+	if err != nil {
+		t.Fatalf("write file error: %+v", err)
+	}
+
+	_, err = OpenMmap(corrupt)
+	if err != ErrTruncated {
+		t.Errorf("ErrTruncated was not returned for an overflowing row count: %v", err)
+	}
+}
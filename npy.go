@@ -0,0 +1,215 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	npyMagic = "\x93NUMPY"
+
+	// npyAlignment is the alignment in bytes of the
+	// values that follow an .npy header.
+	npyAlignment = 64
+
+	// npyMaxHeaderSize is the largest header that will be read.
+	// The header of a supported array is far smaller.
+	npyMaxHeaderSize = 1 << 16
+)
+
+// WriteNPY will write the matrix to the writer as a version 1.0
+// NumPy .npy file holding a C-ordered array of little-endian
+// float64 values with the shape (rows, columns).
+func (m *MatrixFloat64) WriteNPY(w io.Writer) error {
+	header := fmt.Sprintf("{'descr': '<f8', 'fortran_order': False, 'shape': (%d, %d), }", m.Rows(), m.columns)
+
+	// the magic string, version and header length take 10 bytes and
+	// the header is padded with spaces and ends with a newline
+	prefix := len(npyMagic) + 4
+	padding := npyAlignment - (prefix+len(header)+1)%npyAlignment
+	if padding == npyAlignment {
+		padding = 0
+	}
+
+	buf := make([]byte, 0, prefix+len(header)+padding+1)
+	buf = append(buf, npyMagic...)
+	buf = append(buf, 1, 0)
+	buf = append(buf, 0, 0)
+	binary.LittleEndian.PutUint16(buf[len(npyMagic)+2:], uint16(len(header)+padding+1))
+	buf = append(buf, header...)
+	for i := 0; i < padding; i++ {
+		buf = append(buf, ' ')
+	}
+	buf = append(buf, '\n')
+
+	_, err := w.Write(buf)
+	if err != nil {
+		return err
+	}
+
+	values := make([]byte, 8*m.columns)
+	for i := 0; i < len(m.data); i += m.columns {
+		for j, v := range m.data[i : i+m.columns] {
+			binary.LittleEndian.PutUint64(values[j*8:], math.Float64bits(v))
+		}
+
+		_, err = w.Write(values)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ReadNPY will read a matrix from a NumPy .npy file holding a
+// two-dimensional, C-ordered array of little-endian float64 values.
+// If the file is not a valid .npy file, holds any other kind of array,
+// or has a header or shape too large to be read then an
+// ErrInvalidFormat will be returned, and if the file
+// ends before every value has been read then an ErrTruncated will be
+// returned.
+// Memory is only allocated for values as they are read, so a header
+// with a corrupt shape cannot cause a large allocation.
+func ReadNPY(r io.Reader) (*MatrixFloat64, error) {
+	prefix := make([]byte, len(npyMagic)+2)
+	err := readFull(r, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	if string(prefix[:len(npyMagic)]) != npyMagic {
+		return nil, ErrInvalidFormat
+	}
+
+	var headerLen int
+	switch prefix[len(npyMagic)] {
+	case 1:
+		b := make([]byte, 2)
+		err = readFull(r, b)
+		headerLen = int(binary.LittleEndian.Uint16(b))
+	case 2, 3:
+		b := make([]byte, 4)
+		err = readFull(r, b)
+		headerLen = int(binary.LittleEndian.Uint32(b))
+	default:
+		return nil, ErrInvalidFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if headerLen > npyMaxHeaderSize {
+		return nil, ErrInvalidFormat
+	}
+
+	header := make([]byte, headerLen)
+	err = readFull(r, header)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, columns, err := parseNPYHeader(string(header))
+	if err != nil {
+		return nil, err
+	}
+
+	matrix := NewMatrixFloat64(columns)
+	matrix.data, err = readFloat64s(r, matrix.data, int64(rows)*int64(columns))
+	if err != nil {
+		return nil, err
+	}
+
+	return matrix, nil
+}
+
+// parseNPYHeader returns the shape described by an .npy header
+// if the header describes a supported array.
+func parseNPYHeader(header string) (rows, columns int, err error) {
+	descr := npyHeaderField(header, "descr")
+	if descr != "'<f8'" && descr != `"<f8"` {
+		return 0, 0, ErrInvalidFormat
+	}
+
+	if npyHeaderField(header, "fortran_order") != "False" {
+		return 0, 0, ErrInvalidFormat
+	}
+
+	shape := npyHeaderField(header, "shape")
+	if !strings.HasPrefix(shape, "(") || !strings.HasSuffix(shape, ")") {
+		return 0, 0, ErrInvalidFormat
+	}
+
+	dims := strings.Split(strings.Trim(shape, "()"), ",")
+	if len(dims) != 2 {
+		return 0, 0, ErrInvalidFormat
+	}
+
+	rows, err = strconv.Atoi(strings.TrimSpace(dims[0]))
+	if err != nil || rows < 0 {
+		return 0, 0, ErrInvalidFormat
+	}
+
+	columns, err = strconv.Atoi(strings.TrimSpace(dims[1]))
+	if err != nil || columns < 0 || int64(columns) > maxValues {
+		return 0, 0, ErrInvalidFormat
+	}
+
+	// only an array without rows can have no columns
+	if columns == 0 {
+		if rows != 0 {
+			return 0, 0, ErrInvalidFormat
+		}
+	} else if int64(rows) > maxValues/int64(columns) {
+		return 0, 0, ErrInvalidFormat
+	}
+
+	return rows, columns, nil
+}
+
+// npyHeaderField returns the text of the value of the key in the
+// header dictionary, or an empty string if the key is not present.
+func npyHeaderField(header, key string) string {
+	for _, quote := range []string{"'", `"`} {
+		i := strings.Index(header, quote+key+quote)
+		if i < 0 {
+			continue
+		}
+
+		value := strings.TrimLeft(header[i+len(key)+2:], " ")
+		if !strings.HasPrefix(value, ":") {
+			return ""
+		}
+		value = strings.TrimLeft(value[1:], " ")
+
+		end := strings.IndexAny(value, ",}")
+		if strings.HasPrefix(value, "(") {
+			end = strings.Index(value, ")") + 1
+		}
+		if end <= 0 {
+			return ""
+		}
+
+		return strings.TrimSpace(value[:end])
+	}
+
+	return ""
+}
@@ -0,0 +1,182 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestMatrixFloat64WriteNPY(t *testing.T) {
+	matrix := NewMatrixFloat64(3)
+	matrix.AddRows([][]float64{{1, 2, 3}, {4, 5, 6}})
+
+	var buf bytes.Buffer
+	err := matrix.WriteNPY(&buf)
+	if err != nil {
+		t.Errorf("write npy error: %+v", err)
+	}
+	encoded := buf.Bytes()
+
+	if string(encoded[:6]) != "\x93NUMPY" || encoded[6] != 1 || encoded[7] != 0 {
+		t.Errorf("npy magic string and version %q are incorrect", encoded[:8])
+	}
+
+	headerLen := int(binary.LittleEndian.Uint16(encoded[8:10]))
+	if (10+headerLen)%64 != 0 {
+		t.Errorf("npy header length %d is not aligned to 64 bytes", 10+headerLen)
+	}
+
+	header := string(encoded[10 : 10+headerLen])
+	if !strings.HasSuffix(header, "\n") {
+		t.Errorf("npy header %q does not end with a newline", header)
+	}
+
+	for _, field := range []string{"'descr': '<f8'", "'fortran_order': False", "'shape': (2, 3)"} {
+		if !strings.Contains(header, field) {
+			t.Errorf("npy header %q does not contain %s", header, field)
+		}
+	}
+
+	values := encoded[10+headerLen:]
+	if len(values) != 6*8 {
+		t.Errorf("npy data has %d bytes and not %d", len(values), 6*8)
+	}
+
+	for i := 0; i < 6; i++ {
+		v := math.Float64frombits(binary.LittleEndian.Uint64(values[i*8:]))
+		if v != float64(i+1) {
+			t.Errorf("npy value %v at index %d is not %d", v, i, i+1)
+		}
+	}
+}
+
+func TestReadNPY(t *testing.T) {
+	matrix := NewMatrixFloat64(2)
+	matrix.AddRows([][]float64{{1.5, -2}, {0, 3}, {1e-10, 7}})
+
+	var buf bytes.Buffer
+	matrix.WriteNPY(&buf)
+	encoded := buf.Bytes()
+
+	decoded, err := ReadNPY(bytes.NewReader(encoded))
+	if err != nil {
+		t.Errorf("read npy error: %+v", err)
+	}
+
+	if !decoded.Equal(matrix) {
+		t.Errorf("decoded matrix %v is not %v", decoded.data, matrix.data)
+	}
+
+	// a header written by numpy for a C-ordered array
+	header := "{'descr': '<f8', 'fortran_order': False, 'shape': (1, 2), }"
+	header += strings.Repeat(" ", 128-10-len(header)-1) + "\n"
+	numpy := append([]byte("\x93NUMPY\x01\x00"), byte(len(header)), 0)
+	numpy = append(numpy, header...)
+	for _, v := range []float64{4, 5} {
+		b := make([]byte, 8)
+		binary.LittleEndian.PutUint64(b, math.Float64bits(v))
+		numpy = append(numpy, b...)
+	}
+
+	decoded, err = ReadNPY(bytes.NewReader(numpy))
+	if err != nil {
+		t.Errorf("read npy error: %+v", err)
+	}
+
+	if v, _ := decoded.GetValue(0, 1); v != 5 {
+		t.Errorf("decoded value %v at (0, 1) is not 5", v)
+	}
+
+	for _, unsupported := range []string{
+		"{'descr': '<i8', 'fortran_order': False, 'shape': (1, 2), }",
+		"{'descr': '>f8', 'fortran_order': False, 'shape': (1, 2), }",
+		"{'descr': '<f8', 'fortran_order': True, 'shape': (1, 2), }",
+		"{'descr': '<f8', 'fortran_order': False, 'shape': (2,), }",
+		"{'descr': '<f8', 'fortran_order': False, 'shape': (1, 2, 1), }",
+	} {
+		b := append([]byte("\x93NUMPY\x01\x00"), byte(len(unsupported)), 0)
+		b = append(b, unsupported...)
+		_, err = ReadNPY(bytes.NewReader(b))
+		if err != ErrInvalidFormat {
+			t.Errorf("ErrInvalidFormat was not returned for the header %s", unsupported)
+		}
+	}
+
+	_, err = ReadNPY(bytes.NewReader(encoded[:len(encoded)-1]))
+	if err != ErrTruncated {
+		t.Errorf("ErrTruncated was not returned for truncated data")
+	}
+
+	oversized := "{'descr': '<f8', 'fortran_order': False, 'shape': (1, 2305843009213693952), }"
+	b := append([]byte("\x93NUMPY\x01\x00"), byte(len(oversized)), 0)
+	b = append(b, oversized...)
+	_, err = ReadNPY(bytes.NewReader(b))
+	if err != ErrInvalidFormat {
+		t.Errorf("ErrInvalidFormat was not returned for an oversized shape: %v", err)
+	}
+
+	// large column counts that can be held are not allocated
+	// until their values have been read
+	for shape, expected := range map[string]error{
+		"(0, 1152921504606846975)": nil,
+		"(1, 1125899906842624)":    ErrTruncated,
+	} {
+		large := "{'descr': '<f8', 'fortran_order': False, 'shape': " + shape + ", }"
+		b = append([]byte("\x93NUMPY\x01\x00"), byte(len(large)), 0)
+		b = append(b, large...)
+		_, err = ReadNPY(bytes.NewReader(b))
+		if err != expected {
+			t.Errorf("error %v for shape %s is not %v", err, shape, expected)
+		}
+	}
+
+	b = append([]byte("\x93NUMPY\x02\x00"), 0xff, 0xff, 0xff, 0xff)
+	_, err = ReadNPY(bytes.NewReader(b))
+	if err != ErrInvalidFormat {
+		t.Errorf("ErrInvalidFormat was not returned for an oversized header: %v", err)
+	}
+}
+
+func TestMatrixFloat64NPYNoColumns(t *testing.T) {
+	matrix, _ := FromRows(nil)
+
+	var buf bytes.Buffer
+	err := matrix.WriteNPY(&buf)
+	if err != nil {
+		t.Errorf("write npy error: %+v", err)
+	}
+
+	decoded, err := ReadNPY(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Errorf("read npy error: %+v", err)
+	}
+
+	if r, c := decoded.Dimensions(); r != 0 || c != 0 {
+		t.Errorf("dimensions %dx%d are not 0x0", r, c)
+	}
+
+	// rows cannot be held without columns
+	header := "{'descr': '<f8', 'fortran_order': False, 'shape': (2, 0), }"
+	b := append([]byte("\x93NUMPY\x01\x00"), byte(len(header)), 0)
+	b = append(b, header...)
+	_, err = ReadNPY(bytes.NewReader(b))
+	if err != ErrInvalidFormat {
+		t.Errorf("ErrInvalidFormat was not returned for rows without columns: %v", err)
+	}
+}
@@ -1,6 +1,15 @@
 package matrix
 
-import "github.com/humilityai/sam"
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/humilityai/sam"
+	"gonum.org/v1/gonum/mat"
+	"gorgonia.org/tensor"
+)
 
 /*
 	The data structure used could have been: map[int64]float64, which would have mapped map[coordinates]value.
@@ -32,16 +41,44 @@ func NewSparse() *Sparse {
 	}
 }
 
-// Rows will return the number of rows in the sparse matrix
+// Rows will return the number of rows in the sparse matrix,
+// which is one more than the largest row index that has been set.
 func (s *Sparse) Rows() int {
-	return len(s.Data)
+	rows := 0
+	for i := range s.Data {
+		if i >= rows {
+			rows = i + 1
+		}
+	}
+
+	return rows
 }
 
-// Columns will return the number of columns in the sparse matrix
+// Columns will return the number of columns in the sparse matrix,
+// which is one more than the largest column index that has been set.
 func (s *Sparse) Columns() int {
 	return s.C
 }
 
+// Clone will return a new sparse matrix with a deep copy of the
+// stored values so that changes to the clone do not affect the original.
+func (s *Sparse) Clone() *Sparse {
+	clone := &Sparse{
+		C:    s.C,
+		Data: make(map[int]map[int]float64, len(s.Data)),
+	}
+
+	for i, row := range s.Data {
+		r := make(map[int]float64, len(row))
+		for j, v := range row {
+			r[j] = v
+		}
+		clone.Data[i] = r
+	}
+
+	return clone
+}
+
 // Set will set a float64 value at the specified coordinates in
 // the matrix.
 func (s *Sparse) Set(i, j int, value float64) {
@@ -51,8 +88,8 @@ func (s *Sparse) Set(i, j int, value float64) {
 		row = s.Data[i]
 	}
 
-	if j > s.C {
-		s.C = j
+	if j >= s.C {
+		s.C = j + 1
 	}
 
 	row[j] = value
@@ -65,6 +102,14 @@ func (s *Sparse) Get(i, j int) float64 {
 	return s.Data[i][j]
 }
 
+// GetOK will return the value found at the provided coordinates
+// along with whether a value is actually stored there, which
+// distinguishes a stored zero from coordinates that were never set.
+func (s *Sparse) GetOK(i, j int) (float64, bool) {
+	v, ok := s.Data[i][j]
+	return v, ok
+}
+
 // Increment will add +1 to the value found at the coordinates.
 // If the coordinates do not exist then they will be created.
 func (s *Sparse) Increment(i, j int) {
@@ -74,8 +119,8 @@ func (s *Sparse) Increment(i, j int) {
 		row = s.Data[i]
 	}
 
-	if j > s.C {
-		s.C = j
+	if j >= s.C {
+		s.C = j + 1
 	}
 
 	row[j]++
@@ -100,6 +145,158 @@ func (s *Sparse) GetRow(i int) (values, error) {
 	return v, nil
 }
 
+// RowSums will return the sum of the stored values of each row,
+// keyed by row index. Rows without stored values are not included.
+func (s *Sparse) RowSums() map[int]float64 {
+	sums := make(map[int]float64, len(s.Data))
+	for i, row := range s.Data {
+		var sum float64
+		for _, v := range row {
+			sum += v
+		}
+		sums[i] = sum
+	}
+
+	return sums
+}
+
+// ColumnSums will return the sum of the stored values of each column,
+// keyed by column index. Columns without stored values are not included.
+func (s *Sparse) ColumnSums() map[int]float64 {
+	sums := make(map[int]float64)
+	for _, row := range s.Data {
+		for j, v := range row {
+			sums[j] += v
+		}
+	}
+
+	return sums
+}
+
+// MarshalJSON will encode the sparse matrix as JSON with rows and
+// columns ordered by index, so that the same matrix always produces
+// the same output.
+func (s *Sparse) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(`{"columns":`)
+	buf.WriteString(strconv.Itoa(s.C))
+	buf.WriteString(`,"data":{`)
+
+	for n, i := range sortedKeys(s.Data) {
+		if n > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(`"` + strconv.Itoa(i) + `":{`)
+
+		row := s.Data[i]
+		columns := make([]int, 0, len(row))
+		for j := range row {
+			columns = append(columns, j)
+		}
+		sort.Ints(columns)
+
+		for k, j := range columns {
+			if k > 0 {
+				buf.WriteByte(',')
+			}
+
+			v, err := json.Marshal(row[j])
+			if err != nil {
+				return nil, err
+			}
+
+			buf.WriteString(`"` + strconv.Itoa(j) + `":`)
+			buf.Write(v)
+		}
+		buf.WriteByte('}')
+	}
+	buf.WriteString(`}}`)
+
+	return buf.Bytes(), nil
+}
+
+// Merge will combine every value stored in the other matrix into
+// the matrix. The new value at each of the other matrix's coordinates
+// is combine(a, b), where a is the value in the matrix (0 if it is not
+// stored) and b is the value in the other matrix. Values stored only
+// in the matrix are left unchanged.
+func (s *Sparse) Merge(other *Sparse, combine func(a, b float64) float64) {
+	for i, row := range other.Data {
+		for j, b := range row {
+			s.Set(i, j, combine(s.Data[i][j], b))
+		}
+	}
+}
+
+// Scale will multiply every stored value by the factor.
+// Scaling by zero removes every stored value so that the
+// matrix does not fill up with explicit zeros.
+func (s *Sparse) Scale(factor float64) {
+	if factor == 0 {
+		s.Data = make(map[int]map[int]float64)
+		return
+	}
+
+	for _, row := range s.Data {
+		for j := range row {
+			row[j] *= factor
+		}
+	}
+}
+
+// ToGonum will create and return a new Gonum Dense matrix
+// from the Sparse matrix. Coordinates without a stored value are zero.
+// Gonum matrices cannot be empty, so if the sparse matrix has no rows
+// or columns then an ErrEmptyMatrix will be returned.
+func (s *Sparse) ToGonum() (*mat.Dense, error) {
+	rows, columns := s.Rows(), s.Columns()
+	if rows == 0 || columns == 0 {
+		return nil, ErrEmptyMatrix
+	}
+
+	return mat.NewDense(rows, columns, s.dense()), nil
+}
+
+// ToTensor will create and return a new Gorgonia Tensor (dense) object
+// from the Sparse matrix. Coordinates without a stored value are zero.
+func (s *Sparse) ToTensor() tensor.Tensor {
+	return tensor.NewDense(tensor.Float64, []int{s.Rows(), s.Columns()}, tensor.WithBacking(s.dense()))
+}
+
+// UnmarshalJSON will decode a sparse matrix that was
+// encoded by MarshalJSON. The column count is raised to one
+// more than the largest stored column index if it is smaller,
+// so that data encoded when the column count held the largest
+// column index itself is still read correctly.
+func (s *Sparse) UnmarshalJSON(data []byte) error {
+	var decoded struct {
+		C    int                     `json:"columns"`
+		Data map[int]map[int]float64 `json:"data"`
+	}
+
+	err := json.Unmarshal(data, &decoded)
+	if err != nil {
+		return err
+	}
+
+	if decoded.Data == nil {
+		decoded.Data = make(map[int]map[int]float64)
+	}
+
+	for _, row := range decoded.Data {
+		for j := range row {
+			if j >= decoded.C {
+				decoded.C = j + 1
+			}
+		}
+	}
+
+	s.C = decoded.C
+	s.Data = decoded.Data
+
+	return nil
+}
+
 // Type says the Sparse matrix is a float64 data type.
 func (s *Sparse) Type() string {
 	return sam.Float64Type
@@ -131,3 +328,28 @@ func (v values) Sum() float64 {
 	}
 	return sum
 }
+
+// sortedKeys returns the row indices of the data in increasing order.
+func sortedKeys(data map[int]map[int]float64) []int {
+	keys := make([]int, 0, len(data))
+	for i := range data {
+		keys = append(keys, i)
+	}
+	sort.Ints(keys)
+
+	return keys
+}
+
+// dense returns the values of the matrix as a
+// row-major array with zeros for unset coordinates.
+func (s *Sparse) dense() []float64 {
+	columns := s.Columns()
+	data := make([]float64, s.Rows()*columns)
+	for i, row := range s.Data {
+		for j, v := range row {
+			data[i*columns+j] = v
+		}
+	}
+
+	return data
+}
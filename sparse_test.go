@@ -0,0 +1,264 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestSparseScale(t *testing.T) {
+	s := NewSparse()
+	s.Set(0, 1, 2)
+	s.Set(3, 0, -4)
+
+	s.Scale(0.5)
+	if s.Get(0, 1) != 1 || s.Get(3, 0) != -2 {
+		t.Errorf("scaled values %v and %v are not 1 and -2", s.Get(0, 1), s.Get(3, 0))
+	}
+
+	s.Scale(0)
+	if len(s.Data) != 0 {
+		t.Errorf("scaling by zero left %d rows stored", len(s.Data))
+	}
+
+	if s.Get(0, 1) != 0 {
+		t.Errorf("value %v is not 0 after scaling by zero", s.Get(0, 1))
+	}
+}
+
+func TestSparseRowColumnSums(t *testing.T) {
+	s := NewSparse()
+	s.Set(0, 0, 1)
+	s.Set(0, 5, 2)
+	s.Set(2, 5, 3)
+	s.Increment(7, 1)
+	s.Increment(7, 1)
+
+	rows := s.RowSums()
+	expectedRows := map[int]float64{0: 3, 2: 3, 7: 2}
+	if !reflect.DeepEqual(rows, expectedRows) {
+		t.Errorf("row sums %v are not %v", rows, expectedRows)
+	}
+
+	columns := s.ColumnSums()
+	expectedColumns := map[int]float64{0: 1, 1: 2, 5: 5}
+	if !reflect.DeepEqual(columns, expectedColumns) {
+		t.Errorf("column sums %v are not %v", columns, expectedColumns)
+	}
+}
+
+func TestSparseGetOK(t *testing.T) {
+	s := NewSparse()
+	s.Set(1, 2, 0)
+
+	v, ok := s.GetOK(1, 2)
+	if !ok || v != 0 {
+		t.Errorf("stored zero was reported as (%v, %v)", v, ok)
+	}
+
+	v, ok = s.GetOK(1, 3)
+	if ok || v != 0 {
+		t.Errorf("unset cell in a stored row was reported as (%v, %v)", v, ok)
+	}
+
+	v, ok = s.GetOK(4, 2)
+	if ok || v != 0 {
+		t.Errorf("unset cell in an unset row was reported as (%v, %v)", v, ok)
+	}
+}
+
+func TestSparseClone(t *testing.T) {
+	s := NewSparse()
+	s.Set(0, 0, 1)
+	s.Set(2, 3, 4)
+
+	clone := s.Clone()
+	if !reflect.DeepEqual(clone, s) {
+		t.Errorf("clone %+v is not equal to the original %+v", clone, s)
+	}
+
+	clone.Set(0, 0, 10)
+	clone.Set(2, 1, 5)
+	clone.Set(9, 9, 9)
+
+	if s.Get(0, 0) != 1 {
+		t.Errorf("original value %v was changed by the clone", s.Get(0, 0))
+	}
+
+	if _, ok := s.GetOK(2, 1); ok {
+		t.Errorf("value added to the clone appears in the original")
+	}
+
+	if len(s.Data) != 2 {
+		t.Errorf("original rows %d were changed by the clone", len(s.Data))
+	}
+}
+
+func TestSparseMerge(t *testing.T) {
+	s := NewSparse()
+	s.Set(0, 0, 1)
+	s.Set(0, 1, 5)
+	s.Set(1, 1, -3)
+
+	other := NewSparse()
+	other.Set(0, 0, 4)
+	other.Set(0, 1, 2)
+	other.Set(2, 2, -7)
+
+	s.Merge(other, math.Max)
+
+	expected := map[int]map[int]float64{
+		0: {0: 4, 1: 5},
+		1: {1: -3},
+		2: {2: 0},
+	}
+	if !reflect.DeepEqual(s.Data, expected) {
+		t.Errorf("merged data %v is not %v", s.Data, expected)
+	}
+
+	if len(other.Data) != 2 || other.Get(0, 0) != 4 {
+		t.Errorf("other matrix was changed by merging")
+	}
+}
+
+func TestSparseToTensor(t *testing.T) {
+	s := NewSparse()
+	s.Set(0, 1, 2)
+	s.Set(2, 3, -1)
+
+	if s.Rows() != 3 || s.Columns() != 4 {
+		t.Errorf("sparse dimensions (%d, %d) are not (3, 4)", s.Rows(), s.Columns())
+	}
+
+	dense := s.ToTensor()
+	shape := dense.Shape()
+	if shape[0] != 3 || shape[1] != 4 {
+		t.Errorf("tensor shape %v is not (3, 4)", shape)
+	}
+
+	for _, cell := range []struct {
+		row, column int
+		value       float64
+	}{
+		{0, 1, 2},
+		{2, 3, -1},
+		{0, 0, 0},
+		{1, 2, 0},
+	} {
+		v, err := dense.At(cell.row, cell.column)
+		if err != nil {
+			t.Errorf("tensor at error: %+v", err)
+		}
+		if v.(float64) != cell.value {
+			t.Errorf("tensor value at (%d, %d) is %v and not %v", cell.row, cell.column, v, cell.value)
+		}
+	}
+}
+
+func TestSparseToGonum(t *testing.T) {
+	s := NewSparse()
+	s.Set(1, 0, 3)
+	s.Set(3, 2, 5)
+
+	dense, err := s.ToGonum()
+	if err != nil {
+		t.Errorf("to gonum error: %+v", err)
+	}
+
+	r, c := dense.Dims()
+	if r != s.Rows() || c != s.Columns() {
+		t.Errorf("gonum dimensions (%d, %d) are not (%d, %d)", r, c, s.Rows(), s.Columns())
+	}
+
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			if dense.At(i, j) != s.Get(i, j) {
+				t.Errorf("gonum value at (%d, %d) is %v and not %v", i, j, dense.At(i, j), s.Get(i, j))
+			}
+		}
+	}
+
+	if dense.At(1, 0) != 3 || dense.At(3, 2) != 5 || dense.At(0, 0) != 0 {
+		t.Errorf("gonum values do not match the stored values")
+	}
+
+	_, err = NewSparse().ToGonum()
+	if err != ErrEmptyMatrix {
+		t.Errorf("ErrEmptyMatrix was not returned for an empty sparse matrix")
+	}
+
+	s.Scale(0)
+	_, err = s.ToGonum()
+	if err != ErrEmptyMatrix {
+		t.Errorf("ErrEmptyMatrix was not returned for a sparse matrix scaled by zero")
+	}
+}
+
+func TestSparseJSON(t *testing.T) {
+	s := NewSparse()
+	for _, i := range []int{10, 2, 0, 33, 7} {
+		s.Set(i, i%4, float64(i)/2)
+		s.Set(i, 11, -1)
+	}
+
+	first, err := json.Marshal(s)
+	if err != nil {
+		t.Errorf("marshal error: %+v", err)
+	}
+
+	second, err := json.Marshal(s.Clone())
+	if err != nil {
+		t.Errorf("marshal error: %+v", err)
+	}
+
+	if !bytes.Equal(first, second) {
+		t.Errorf("marshaled output differs:\n%s\n%s", first, second)
+	}
+
+	// rows and columns are in numeric order
+	prefix := `{"columns":12,"data":{"0":{"0":0,"11":-1},"2":{"2":1,"11":-1},"7":`
+	if !bytes.HasPrefix(first, []byte(prefix)) {
+		t.Errorf("marshaled output %s does not start with %s", first, prefix)
+	}
+
+	decoded := &Sparse{}
+	err = json.Unmarshal(first, decoded)
+	if err != nil {
+		t.Errorf("unmarshal error: %+v", err)
+	}
+
+	if !reflect.DeepEqual(decoded, s) {
+		t.Errorf("decoded matrix %+v is not %+v", decoded, s)
+	}
+
+	// the column count was once the largest column index
+	old := &Sparse{}
+	err = json.Unmarshal([]byte(`{"columns":1,"data":{"0":{"1":5}}}`), old)
+	if err != nil {
+		t.Errorf("unmarshal error: %+v", err)
+	}
+
+	if old.Columns() != 2 {
+		t.Errorf("columns %d of the old encoding is not 2", old.Columns())
+	}
+
+	if dense, _ := old.ToGonum(); dense.At(0, 1) != 5 {
+		t.Errorf("value at (0, 1) is not 5")
+	}
+}
@@ -0,0 +1,557 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+import (
+	"math"
+	"sort"
+
+	"github.com/humilityai/sam"
+)
+
+// ColumnStats is a summary of the values of a single column.
+type ColumnStats struct {
+	Count  int
+	Min    float64
+	Max    float64
+	Mean   float64
+	StdDev float64
+	Median float64
+}
+
+// Bin will assign each value of the column to a bin using the
+// sorted edges provided. A value v is placed in bin i when
+// edges[i-1] <= v < edges[i], so values below the first edge are
+// placed in bin 0 and values at or above the last edge are placed
+// in bin len(edges).
+// If the column is out of bounds then an ErrColumnIndex will be returned.
+func (m *MatrixFloat64) Bin(column int, edges sam.SliceFloat64) (sam.SliceInt, error) {
+	if column < 0 || column >= m.columns {
+		return nil, ErrColumnIndex
+	}
+
+	bins := make(sam.SliceInt, 0, m.Rows())
+	for i := column; i < len(m.data); i += m.columns {
+		v := m.data[i]
+		bins = append(bins, sort.Search(len(edges), func(j int) bool {
+			return edges[j] > v
+		}))
+	}
+
+	return bins, nil
+}
+
+// ColumnHistogram will count the values of the column in the
+// specified number of equal-width bins spanning the smallest to the
+// largest value of the column. The edges of the bins are returned
+// along with the counts, so bin i covers [edges[i], edges[i+1]) and
+// the last bin also includes the largest value.
+// Values that are NaN or infinite are not included in the bounds or
+// the counts. If every value in the column is the same then the bins
+// span [value-0.5, value+0.5].
+// If the column is out of bounds then an ErrColumnIndex will be returned,
+// if bins is less than 1 then an ErrInvalidArgument will be returned and
+// if the column has no finite values then an ErrEmptyMatrix will be returned.
+func (m *MatrixFloat64) ColumnHistogram(column, bins int) (counts sam.SliceInt, edges sam.SliceFloat64, err error) {
+	if column < 0 || column >= m.columns {
+		return counts, edges, ErrColumnIndex
+	} else if bins < 1 {
+		return counts, edges, ErrInvalidArgument
+	}
+
+	values := finite(m.column(column))
+	if len(values) == 0 {
+		return counts, edges, ErrEmptyMatrix
+	}
+
+	min, max := values[0], values[0]
+	for _, v := range values {
+		if v < min {
+			min = v
+		} else if v > max {
+			max = v
+		}
+	}
+	if min == max {
+		min -= 0.5
+		max += 0.5
+	}
+
+	width := (max - min) / float64(bins)
+	edges = make(sam.SliceFloat64, bins+1)
+	for i := range edges {
+		edges[i] = min + float64(i)*width
+	}
+	edges[bins] = max
+
+	counts = make(sam.SliceInt, bins)
+	for _, v := range values {
+		bin := int((v - min) / width)
+		if bin >= bins {
+			bin = bins - 1
+		}
+		counts[bin]++
+	}
+
+	return counts, edges, nil
+}
+
+// ColumnMaxes will return the largest value found
+// in each column of the matrix.
+// An empty array is returned if the matrix has no rows.
+func (m *MatrixFloat64) ColumnMaxes() sam.SliceFloat64 {
+	if len(m.data) == 0 {
+		return sam.SliceFloat64{}
+	}
+
+	maxes := make(sam.SliceFloat64, m.columns)
+	copy(maxes, m.data[:m.columns])
+	for i := m.columns; i < len(m.data); i += m.columns {
+		for j, v := range m.data[i : i+m.columns] {
+			if v > maxes[j] {
+				maxes[j] = v
+			}
+		}
+	}
+
+	return maxes
+}
+
+// ColumnMeans will return the average value of each column
+// of the matrix.
+// An empty array is returned if the matrix has no rows.
+func (m *MatrixFloat64) ColumnMeans() sam.SliceFloat64 {
+	if len(m.data) == 0 {
+		return sam.SliceFloat64{}
+	}
+
+	means := make(sam.SliceFloat64, m.columns)
+	for i, v := range m.data {
+		means[i%m.columns] += v
+	}
+
+	rows := float64(m.Rows())
+	for j := range means {
+		means[j] /= rows
+	}
+
+	return means
+}
+
+// ColumnMedians will return the median value of each column
+// of the matrix. For an even number of rows the median is the
+// average of the two middle values.
+// An empty array is returned if the matrix has no rows.
+func (m *MatrixFloat64) ColumnMedians() sam.SliceFloat64 {
+	if len(m.data) == 0 {
+		return sam.SliceFloat64{}
+	}
+
+	medians := make(sam.SliceFloat64, m.columns)
+	for j := range medians {
+		medians[j] = median(m.column(j))
+	}
+
+	return medians
+}
+
+// ColumnMins will return the smallest value found
+// in each column of the matrix.
+// An empty array is returned if the matrix has no rows.
+func (m *MatrixFloat64) ColumnMins() sam.SliceFloat64 {
+	if len(m.data) == 0 {
+		return sam.SliceFloat64{}
+	}
+
+	mins := make(sam.SliceFloat64, m.columns)
+	copy(mins, m.data[:m.columns])
+	for i := m.columns; i < len(m.data); i += m.columns {
+		for j, v := range m.data[i : i+m.columns] {
+			if v < mins[j] {
+				mins[j] = v
+			}
+		}
+	}
+
+	return mins
+}
+
+// ColumnQuantile will return the q-th quantile of the values in
+// the column, where q is within [0, 1]. The quantile is linearly
+// interpolated between the two nearest ranked values. Values that
+// are NaN are not ranked.
+// If the column is out of bounds then an ErrColumnIndex will be returned,
+// if q is not within [0, 1] then an ErrInvalidArgument will be returned and
+// if the column has no values other than NaN then an ErrEmptyMatrix will
+// be returned.
+func (m *MatrixFloat64) ColumnQuantile(column int, q float64) (float64, error) {
+	if column < 0 || column >= m.columns {
+		return 0, ErrColumnIndex
+	} else if !(q >= 0 && q <= 1) {
+		return 0, ErrInvalidArgument
+	}
+
+	values := withoutNaN(m.column(column))
+	if len(values) == 0 {
+		return 0, ErrEmptyMatrix
+	}
+	sort.Float64s(values)
+
+	return quantile(values, q), nil
+}
+
+// ColumnStats will return the smallest value, the largest value and
+// the sum of each column of the matrix, which are found in a single
+// pass over the matrix rather than the three passes of ColumnMins,
+// ColumnMaxes and a sum of each column.
+// Empty arrays are returned if the matrix has no rows.
+func (m *MatrixFloat64) ColumnStats() (mins, maxes, sums sam.SliceFloat64) {
+	if len(m.data) == 0 {
+		return sam.SliceFloat64{}, sam.SliceFloat64{}, sam.SliceFloat64{}
+	}
+
+	mins = make(sam.SliceFloat64, m.columns)
+	maxes = make(sam.SliceFloat64, m.columns)
+	sums = make(sam.SliceFloat64, m.columns)
+	copy(mins, m.data[:m.columns])
+	copy(maxes, m.data[:m.columns])
+	for i := 0; i < len(m.data); i += m.columns {
+		for j, v := range m.data[i : i+m.columns] {
+			if v < mins[j] {
+				mins[j] = v
+			} else if v > maxes[j] {
+				maxes[j] = v
+			}
+			sums[j] += v
+		}
+	}
+
+	return mins, maxes, sums
+}
+
+// ColumnVariances will return the sample variance of each column
+// of the matrix. The variance of every column is NaN if the
+// matrix has a single row.
+// An empty array is returned if the matrix has no rows.
+func (m *MatrixFloat64) ColumnVariances() sam.SliceFloat64 {
+	means := m.ColumnMeans()
+	variances := make(sam.SliceFloat64, len(means))
+	for i, v := range m.data {
+		d := v - means[i%m.columns]
+		variances[i%m.columns] += d * d
+	}
+
+	n := float64(m.Rows() - 1)
+	for j := range variances {
+		variances[j] /= n
+	}
+
+	return variances
+}
+
+// ConstantColumns will return the indices of the columns whose
+// values are the same in every row, which includes columns that
+// are entirely NaN.
+// An empty array is returned if the matrix has no rows.
+func (m *MatrixFloat64) ConstantColumns() sam.SliceInt {
+	constant := sam.SliceInt{}
+	if len(m.data) == 0 {
+		return constant
+	}
+
+	for j := 0; j < m.columns; j++ {
+		first := m.data[j]
+		same := true
+		for i := j + m.columns; i < len(m.data); i += m.columns {
+			v := m.data[i]
+			if v != first && !(math.IsNaN(v) && math.IsNaN(first)) {
+				same = false
+				break
+			}
+		}
+
+		if same {
+			constant = append(constant, j)
+		}
+	}
+
+	return constant
+}
+
+// Correlation will return the Pearson correlation matrix of the
+// matrix, treating each column as a variable and each row as an
+// observation. The diagonal of the result is always 1. A column
+// with zero variance has no defined correlation, so its
+// off-diagonal values are NaN.
+// If the matrix has no rows then an ErrEmptyMatrix will be returned
+// and if it has a single row then an ErrInsufficientRows will be returned.
+func (m *MatrixFloat64) Correlation() (*MatrixFloat64, error) {
+	correlation, err := m.Covariance()
+	if err != nil {
+		return nil, err
+	}
+
+	deviations := make(sam.SliceFloat64, m.columns)
+	for j := range deviations {
+		deviations[j] = math.Sqrt(correlation.data[j*m.columns+j])
+	}
+
+	for j := 0; j < m.columns; j++ {
+		for k := 0; k < m.columns; k++ {
+			if j == k {
+				correlation.data[j*m.columns+k] = 1
+			} else if deviations[j] == 0 || deviations[k] == 0 {
+				correlation.data[j*m.columns+k] = math.NaN()
+			} else {
+				correlation.data[j*m.columns+k] /= deviations[j] * deviations[k]
+			}
+		}
+	}
+
+	return correlation, nil
+}
+
+// Covariance will return the sample covariance matrix of the
+// matrix, treating each column as a variable and each row as an
+// observation. The result has one row and column for each column.
+// If the matrix has no rows then an ErrEmptyMatrix will be returned
+// and if it has a single row then an ErrInsufficientRows will be returned.
+func (m *MatrixFloat64) Covariance() (*MatrixFloat64, error) {
+	rows := m.Rows()
+	if rows == 0 {
+		return nil, ErrEmptyMatrix
+	} else if rows < 2 {
+		return nil, ErrInsufficientRows
+	}
+
+	means := m.ColumnMeans()
+	data := make(sam.SliceFloat64, m.columns*m.columns)
+	for i := 0; i < len(m.data); i += m.columns {
+		row := m.data[i : i+m.columns]
+		for j := 0; j < m.columns; j++ {
+			dj := row[j] - means[j]
+			for k := j; k < m.columns; k++ {
+				data[j*m.columns+k] += dj * (row[k] - means[k])
+			}
+		}
+	}
+
+	n := float64(rows - 1)
+	for j := 0; j < m.columns; j++ {
+		for k := j; k < m.columns; k++ {
+			data[j*m.columns+k] /= n
+			data[k*m.columns+j] = data[j*m.columns+k]
+		}
+	}
+
+	return &MatrixFloat64{
+		data:    data,
+		columns: m.columns,
+	}, nil
+}
+
+// CumulativeColumnSums will return a new matrix in which each
+// value is the sum of its column from the first row through the
+// row of the value.
+func (m *MatrixFloat64) CumulativeColumnSums() *MatrixFloat64 {
+	data := make(sam.SliceFloat64, len(m.data))
+	copy(data, m.data)
+	for i := m.columns; i < len(data); i++ {
+		data[i] += data[i-m.columns]
+	}
+
+	return &MatrixFloat64{
+		data:    data,
+		columns: m.columns,
+	}
+}
+
+// Describe will return a summary of the finite values found in
+// each column of the matrix. Values that are NaN or infinite are
+// not included in any of the statistics.
+// The standard deviation is the sample standard deviation and is
+// NaN when a column has fewer than two finite values. Every statistic
+// other than the count is NaN for a column with no finite values.
+func (m *MatrixFloat64) Describe() []ColumnStats {
+	stats := make([]ColumnStats, m.columns)
+	for j := range stats {
+		stats[j] = describe(finite(m.column(j)))
+	}
+
+	return stats
+}
+
+// ImputeColumnMeans will replace every NaN value in the matrix
+// with the mean of the finite values found in the same column.
+// Columns that contain no finite values are left unchanged.
+func (m *MatrixFloat64) ImputeColumnMeans() {
+	sums := make(sam.SliceFloat64, m.columns)
+	counts := make([]int, m.columns)
+	for i, v := range m.data {
+		if !math.IsNaN(v) && !math.IsInf(v, 0) {
+			sums[i%m.columns] += v
+			counts[i%m.columns]++
+		}
+	}
+
+	for i, v := range m.data {
+		j := i % m.columns
+		if math.IsNaN(v) && counts[j] > 0 {
+			m.data[i] = sums[j] / float64(counts[j])
+		}
+	}
+}
+
+// Mean will return the average of every value in the matrix.
+// NaN is returned if the matrix has no values.
+func (m *MatrixFloat64) Mean() float64 {
+	if len(m.data) == 0 {
+		return math.NaN()
+	}
+
+	return m.Sum() / float64(len(m.data))
+}
+
+// Sum will return the sum of every value in the matrix.
+func (m *MatrixFloat64) Sum() float64 {
+	return m.data.Sum()
+}
+
+// Winsorize will clamp the values of each column in place to the
+// lowerQ and upperQ quantiles of that column. Values that are NaN
+// are not used to find the quantiles and are left unchanged.
+// If either quantile is not within [0, 1] or lowerQ is greater than
+// upperQ then an ErrInvalidArgument will be returned.
+func (m *MatrixFloat64) Winsorize(lowerQ, upperQ float64) error {
+	if !(lowerQ >= 0 && upperQ <= 1 && lowerQ <= upperQ) {
+		return ErrInvalidArgument
+	}
+
+	for j := 0; j < m.columns; j++ {
+		values := withoutNaN(m.column(j))
+		if len(values) == 0 {
+			continue
+		}
+		sort.Float64s(values)
+		lower, upper := quantile(values, lowerQ), quantile(values, upperQ)
+
+		for i := j; i < len(m.data); i += m.columns {
+			if m.data[i] < lower {
+				m.data[i] = lower
+			} else if m.data[i] > upper {
+				m.data[i] = upper
+			}
+		}
+	}
+
+	return nil
+}
+
+// describe summarizes the values, sorting them in place.
+func describe(values sam.SliceFloat64) ColumnStats {
+	stats := ColumnStats{
+		Count:  len(values),
+		Min:    math.NaN(),
+		Max:    math.NaN(),
+		Mean:   math.NaN(),
+		StdDev: math.NaN(),
+		Median: math.NaN(),
+	}
+
+	if stats.Count == 0 {
+		return stats
+	}
+
+	stats.Mean = values.Sum() / float64(stats.Count)
+	if stats.Count > 1 {
+		var sum float64
+		for _, v := range values {
+			sum += (v - stats.Mean) * (v - stats.Mean)
+		}
+		stats.StdDev = math.Sqrt(sum / float64(stats.Count-1))
+	}
+
+	stats.Median = median(values)
+	stats.Min = values[0]
+	stats.Max = values[stats.Count-1]
+
+	return stats
+}
+
+// finite returns the values that are neither NaN nor infinite.
+func finite(values sam.SliceFloat64) sam.SliceFloat64 {
+	var result sam.SliceFloat64
+	for _, v := range values {
+		if !math.IsNaN(v) && !math.IsInf(v, 0) {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
+
+// withoutNaN returns the values that are not NaN.
+func withoutNaN(values sam.SliceFloat64) sam.SliceFloat64 {
+	var result sam.SliceFloat64
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
+
+// median sorts the values in place and returns their median.
+func median(values sam.SliceFloat64) float64 {
+	sort.Float64s(values)
+
+	n := len(values)
+	if n%2 == 1 {
+		return values[n/2]
+	}
+
+	return (values[n/2-1] + values[n/2]) / 2
+}
+
+// quantile returns the q-th quantile of the sorted values.
+// Interpolating between a value and an infinity gives the
+// infinity, and between -Inf and +Inf gives the nearer one.
+func quantile(values sam.SliceFloat64, q float64) float64 {
+	h := q * float64(len(values)-1)
+	lower := math.Floor(h)
+	i := int(lower)
+	if h == lower || i+1 >= len(values) {
+		return values[i]
+	}
+
+	low, high := values[i], values[i+1]
+	fraction := h - lower
+	switch {
+	case low == high:
+		return low
+	case math.IsInf(low, -1) && math.IsInf(high, 1):
+		if fraction < 0.5 {
+			return low
+		}
+		return high
+	case math.IsInf(low, -1):
+		return low
+	case math.IsInf(high, 1):
+		return high
+	}
+
+	return low + fraction*(high-low)
+}
@@ -0,0 +1,558 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/humilityai/sam"
+)
+
+func TestMatrixFloat64ColumnMinsMaxes(t *testing.T) {
+	matrix := NewMatrixFloat64(3)
+	matrix.AddRow([]float64{-1, 5, -10})
+	matrix.AddRow([]float64{-3, -2, -20})
+	matrix.AddRow([]float64{2, 0, -5})
+
+	mins := matrix.ColumnMins()
+	if !mins.Equal(sam.SliceFloat64{-3, -2, -20}) {
+		t.Errorf("column mins %v are not [-3 -2 -20]", mins)
+	}
+
+	maxes := matrix.ColumnMaxes()
+	if !maxes.Equal(sam.SliceFloat64{2, 5, -5}) {
+		t.Errorf("column maxes %v are not [2 5 -5]", maxes)
+	}
+
+	empty := NewMatrixFloat64(3)
+	if len(empty.ColumnMins()) != 0 || len(empty.ColumnMaxes()) != 0 {
+		t.Errorf("column mins and maxes of an empty matrix are not empty")
+	}
+}
+
+func TestMatrixFloat64ColumnMedians(t *testing.T) {
+	odd := NewMatrixFloat64(2)
+	odd.AddRow([]float64{3, 10})
+	odd.AddRow([]float64{1, 30})
+	odd.AddRow([]float64{2, 20})
+
+	medians := odd.ColumnMedians()
+	if !medians.Equal(sam.SliceFloat64{2, 20}) {
+		t.Errorf("odd medians %v are not [2 20]", medians)
+	}
+
+	// the original column order is not changed
+	v, _ := odd.GetValue(0, 0)
+	if v != 3 {
+		t.Errorf("value %v at (0, 0) was changed by computing the median", v)
+	}
+
+	even := NewMatrixFloat64(1)
+	even.AddRow([]float64{4})
+	even.AddRow([]float64{1})
+	even.AddRow([]float64{100})
+	even.AddRow([]float64{2})
+
+	medians = even.ColumnMedians()
+	if !medians.Equal(sam.SliceFloat64{3}) {
+		t.Errorf("even medians %v are not [3]", medians)
+	}
+}
+
+func TestMatrixFloat64ImputeColumnMeans(t *testing.T) {
+	nan := math.NaN()
+	matrix := NewMatrixFloat64(3)
+	matrix.AddRow([]float64{1, nan, nan})
+	matrix.AddRow([]float64{nan, 4, nan})
+	matrix.AddRow([]float64{3, 8, nan})
+	matrix.AddRow([]float64{5, nan, nan})
+
+	matrix.ImputeColumnMeans()
+
+	expected := [][]float64{
+		{1, 6},
+		{3, 4},
+		{3, 8},
+		{5, 6},
+	}
+	for i, row := range expected {
+		for j, e := range row {
+			v, _ := matrix.GetValue(i, j)
+			if v != e {
+				t.Errorf("value at (%d, %d) is %v and not %v", i, j, v, e)
+			}
+		}
+
+		// a column of only NaN values is left as NaN
+		v, _ := matrix.GetValue(i, 2)
+		if !math.IsNaN(v) {
+			t.Errorf("value at (%d, 2) is %v and not NaN", i, v)
+		}
+	}
+}
+
+func TestMatrixFloat64Covariance(t *testing.T) {
+	matrix := NewMatrixFloat64(2)
+	matrix.AddRow([]float64{1, 2})
+	matrix.AddRow([]float64{2, 1})
+	matrix.AddRow([]float64{3, 6})
+
+	// means are 2 and 3
+	// var(x) = (1 + 0 + 1) / 2 = 1
+	// var(y) = (1 + 4 + 9) / 2 = 7
+	// cov(x, y) = (1 + 0 + 3) / 2 = 2
+	covariance, err := matrix.Covariance()
+	if err != nil {
+		t.Errorf("covariance error: %+v", err)
+	}
+
+	expected := sam.SliceFloat64{1, 2, 2, 7}
+	for i, e := range expected {
+		if math.Abs(covariance.data[i]-e) > 1e-12 {
+			t.Errorf("covariance value %v at index %d is not %v", covariance.data[i], i, e)
+		}
+	}
+
+	single := NewMatrixFloat64(2)
+	single.AddRow([]float64{1, 2})
+	_, err = single.Covariance()
+	if err != ErrInsufficientRows {
+		t.Errorf("ErrInsufficientRows was not returned for a single row")
+	}
+}
+
+func TestMatrixFloat64Correlation(t *testing.T) {
+	matrix := NewMatrixFloat64(4)
+	matrix.AddRow([]float64{1, 2, 5, 7})
+	matrix.AddRow([]float64{2, 4, 3, 7})
+	matrix.AddRow([]float64{3, 6, 1, 7})
+	matrix.AddRow([]float64{4, 8, 0, 7})
+
+	correlation, err := matrix.Correlation()
+	if err != nil {
+		t.Errorf("correlation error: %+v", err)
+	}
+
+	for j := 0; j < 4; j++ {
+		v, _ := correlation.GetValue(j, j)
+		if v != 1 {
+			t.Errorf("diagonal value %v at %d is not 1", v, j)
+		}
+	}
+
+	v, _ := correlation.GetValue(0, 1)
+	if math.Abs(v-1) > 1e-12 {
+		t.Errorf("correlation %v of perfectly correlated columns is not 1", v)
+	}
+
+	v, _ = correlation.GetValue(2, 0)
+	if v >= 0 {
+		t.Errorf("correlation %v of inversely related columns is not negative", v)
+	}
+
+	v, _ = correlation.GetValue(3, 0)
+	if !math.IsNaN(v) {
+		t.Errorf("correlation %v with a constant column is not NaN", v)
+	}
+}
+
+func TestMatrixFloat64Bin(t *testing.T) {
+	matrix := NewMatrixFloat64(2)
+	for _, v := range []float64{-5, 0, 0.5, 1, 9.99, 10, 42} {
+		matrix.AddRow([]float64{0, v})
+	}
+
+	bins, err := matrix.Bin(1, sam.SliceFloat64{0, 1, 10})
+	if err != nil {
+		t.Errorf("bin error: %+v", err)
+	}
+
+	for i, e := range []int{0, 1, 1, 2, 2, 3, 3} {
+		if bins[i] != e {
+			t.Errorf("bin %d at row %d is not %d", bins[i], i, e)
+		}
+	}
+
+	_, err = matrix.Bin(2, sam.SliceFloat64{0})
+	if err != ErrColumnIndex {
+		t.Errorf("ErrColumnIndex was not returned for an out of bounds column")
+	}
+}
+
+func TestMatrixFloat64ColumnHistogram(t *testing.T) {
+	matrix := NewMatrixFloat64(2)
+	for i := 0; i < 10; i++ {
+		matrix.AddRow([]float64{float64(i), 3})
+	}
+
+	counts, edges, err := matrix.ColumnHistogram(0, 5)
+	if err != nil {
+		t.Errorf("histogram error: %+v", err)
+	}
+
+	for i, count := range counts {
+		if count != 2 {
+			t.Errorf("bin %d has %d values and not 2", i, count)
+		}
+	}
+
+	if len(edges) != 6 || edges[0] != 0 || edges[5] != 9 {
+		t.Errorf("edges %v do not span [0, 9] in 5 bins", edges)
+	}
+
+	// all values are the same
+	counts, edges, err = matrix.ColumnHistogram(1, 3)
+	if err != nil {
+		t.Errorf("histogram error: %+v", err)
+	}
+
+	if counts.Sum() != 10 || counts[1] != 10 {
+		t.Errorf("constant column counts %v do not place every value in the middle bin", counts)
+	}
+
+	if edges[0] != 2.5 || edges[3] != 3.5 {
+		t.Errorf("constant column edges %v do not span [2.5, 3.5]", edges)
+	}
+
+	// negative values
+	negative := NewMatrixFloat64(1)
+	negative.AddRow([]float64{-4})
+	negative.AddRow([]float64{-2})
+	negative.AddRow([]float64{-1})
+
+	counts, edges, err = negative.ColumnHistogram(0, 3)
+	if err != nil {
+		t.Errorf("histogram error: %+v", err)
+	}
+
+	if counts[0] != 1 || counts[2] != 2 || edges[0] != -4 || edges[3] != -1 {
+		t.Errorf("negative column histogram %v with edges %v is incorrect", counts, edges)
+	}
+
+	// values that are not finite are left out
+	missing := NewMatrixFloat64(1)
+	missing.AddRows([][]float64{{math.NaN()}, {1}, {math.Inf(1)}, {3}, {math.NaN()}})
+	counts, edges, err = missing.ColumnHistogram(0, 2)
+	if err != nil {
+		t.Errorf("histogram error: %+v", err)
+	}
+
+	if counts[0] != 1 || counts[1] != 1 || edges[0] != 1 || edges[2] != 3 {
+		t.Errorf("histogram %v with edges %v of a column with NaN is incorrect", counts, edges)
+	}
+
+	allMissing := NewMatrixFloat64(1)
+	allMissing.AddRow([]float64{math.NaN()})
+	_, _, err = allMissing.ColumnHistogram(0, 2)
+	if err != ErrEmptyMatrix {
+		t.Errorf("ErrEmptyMatrix was not returned for a column with no finite values")
+	}
+
+	_, _, err = matrix.ColumnHistogram(0, 0)
+	if err != ErrInvalidArgument {
+		t.Errorf("ErrInvalidArgument was not returned for zero bins")
+	}
+}
+
+func TestMatrixFloat64CumulativeColumnSums(t *testing.T) {
+	matrix := NewMatrixFloat64(3)
+	matrix.AddRow([]float64{1, 0, -1})
+	matrix.AddRow([]float64{2, 5, -1})
+	matrix.AddRow([]float64{3, 1, 4})
+
+	sums := matrix.CumulativeColumnSums()
+
+	middle := sam.SliceFloat64(sums.Values(1))
+	if !middle.Equal(sam.SliceFloat64{3, 5, -2}) {
+		t.Errorf("running totals %v are not [3 5 -2]", middle)
+	}
+
+	totals := make(sam.SliceFloat64, matrix.Columns())
+	for j := range totals {
+		totals[j] = matrix.column(j).Sum()
+	}
+
+	last := sam.SliceFloat64(sums.Values(2))
+	if !last.Equal(totals) {
+		t.Errorf("last row %v is not the column totals %v", last, totals)
+	}
+}
+
+func TestMatrixFloat64Describe(t *testing.T) {
+	matrix := NewMatrixFloat64(3)
+	matrix.AddRow([]float64{1, -2, math.NaN()})
+	matrix.AddRow([]float64{2, math.Inf(1), math.NaN()})
+	matrix.AddRow([]float64{3, 4, math.NaN()})
+	matrix.AddRow([]float64{6, 1, 5})
+
+	stats := matrix.Describe()
+	if len(stats) != 3 {
+		t.Errorf("described %d columns and not 3", len(stats))
+	}
+
+	first := stats[0]
+	if first.Count != 4 || first.Min != 1 || first.Max != 6 || first.Mean != 3 || first.Median != 2.5 {
+		t.Errorf("first column stats %+v are incorrect", first)
+	}
+
+	// sum of squared deviations is 4 + 1 + 0 + 9 = 14
+	if math.Abs(first.StdDev-math.Sqrt(14.0/3)) > 1e-12 {
+		t.Errorf("first column standard deviation %v is not %v", first.StdDev, math.Sqrt(14.0/3))
+	}
+
+	second := stats[1]
+	if second.Count != 3 || second.Min != -2 || second.Max != 4 || second.Mean != 1 || second.Median != 1 {
+		t.Errorf("second column stats %+v are incorrect", second)
+	}
+
+	third := stats[2]
+	if third.Count != 1 || third.Mean != 5 || !math.IsNaN(third.StdDev) {
+		t.Errorf("third column stats %+v are incorrect", third)
+	}
+}
+
+func TestMatrixFloat64SumMean(t *testing.T) {
+	matrix := NewMatrixFloat64(3)
+	matrix.AddRow([]float64{1, 2, 3})
+	matrix.AddRow([]float64{4, 5, -3})
+
+	if matrix.Sum() != 12 {
+		t.Errorf("sum %v is not 12", matrix.Sum())
+	}
+
+	if matrix.Mean() != 2 {
+		t.Errorf("mean %v is not 2", matrix.Mean())
+	}
+
+	empty := NewMatrixFloat64(3)
+	if empty.Sum() != 0 {
+		t.Errorf("sum of an empty matrix %v is not 0", empty.Sum())
+	}
+
+	if !math.IsNaN(empty.Mean()) {
+		t.Errorf("mean of an empty matrix %v is not NaN", empty.Mean())
+	}
+}
+
+func TestMatrixFloat64ColumnQuantile(t *testing.T) {
+	matrix := NewMatrixFloat64(1)
+	for _, v := range []float64{50, 10, 40, 20, 30, 100, 60, 90, 70, 80, 0} {
+		matrix.AddRow([]float64{v})
+	}
+
+	median, err := matrix.ColumnQuantile(0, 0.5)
+	if err != nil {
+		t.Errorf("quantile error: %+v", err)
+	}
+	if median != 50 {
+		t.Errorf("median %v is not 50", median)
+	}
+
+	p90, _ := matrix.ColumnQuantile(0, 0.9)
+	if p90 != 90 {
+		t.Errorf("90th percentile %v is not 90", p90)
+	}
+
+	// interpolated between 90 and 100
+	p95, _ := matrix.ColumnQuantile(0, 0.95)
+	if math.Abs(p95-95) > 1e-9 {
+		t.Errorf("95th percentile %v is not 95", p95)
+	}
+
+	max, _ := matrix.ColumnQuantile(0, 1)
+	if max != 100 {
+		t.Errorf("maximum %v is not 100", max)
+	}
+
+	_, err = matrix.ColumnQuantile(0, 1.5)
+	if err != ErrInvalidArgument {
+		t.Errorf("ErrInvalidArgument was not returned for q of 1.5")
+	}
+
+	_, err = matrix.ColumnQuantile(1, 0.5)
+	if err != ErrColumnIndex {
+		t.Errorf("ErrColumnIndex was not returned for an out of bounds column")
+	}
+
+	_, err = matrix.ColumnQuantile(0, math.NaN())
+	if err != ErrInvalidArgument {
+		t.Errorf("ErrInvalidArgument was not returned for a NaN q")
+	}
+
+	// NaN values are not ranked
+	missing := NewMatrixFloat64(1)
+	missing.AddRows([][]float64{{math.NaN()}, {1}, {3}, {math.NaN()}, {2}})
+	for q, expected := range map[float64]float64{0: 1, 0.25: 1.5, 1: 3} {
+		v, err := missing.ColumnQuantile(0, q)
+		if err != nil {
+			t.Errorf("quantile error: %+v", err)
+		}
+		if v != expected {
+			t.Errorf("quantile %v of a column with NaN is %v and not %v", q, v, expected)
+		}
+	}
+
+	allMissing := NewMatrixFloat64(1)
+	allMissing.AddRow([]float64{math.NaN()})
+	_, err = allMissing.ColumnQuantile(0, 0.5)
+	if err != ErrEmptyMatrix {
+		t.Errorf("ErrEmptyMatrix was not returned for a column of only NaN")
+	}
+
+	// infinite values are ranked but not interpolated into
+	inf, negInf := math.Inf(1), math.Inf(-1)
+	infinite := NewMatrixFloat64(2)
+	infinite.AddRows([][]float64{{1, negInf}, {2, negInf}, {inf, inf}})
+	for _, c := range []struct {
+		column   int
+		q, value float64
+	}{
+		{0, 0.5, 2},
+		{0, 0.25, 1.5},
+		{0, 0.75, inf},
+		{0, 1, inf},
+		{1, 0.25, negInf},
+		{1, 0.5, negInf},
+		{1, 0.6, negInf},
+		{1, 0.9, inf},
+	} {
+		v, err := infinite.ColumnQuantile(c.column, c.q)
+		if err != nil {
+			t.Errorf("quantile error: %+v", err)
+		}
+		if v != c.value {
+			t.Errorf("quantile %v of column %d with infinities is %v and not %v", c.q, c.column, v, c.value)
+		}
+	}
+}
+
+func TestMatrixFloat64Winsorize(t *testing.T) {
+	matrix := NewMatrixFloat64(2)
+	for i := 0; i <= 10; i++ {
+		matrix.AddRow([]float64{float64(i * 10), 5})
+	}
+	matrix.UpdateValue(-1000, 0, 0)
+	matrix.UpdateValue(1000, 10, 0)
+
+	err := matrix.Winsorize(0.1, 0.9)
+	if err != nil {
+		t.Errorf("winsorize error: %+v", err)
+	}
+
+	v, _ := matrix.GetValue(0, 0)
+	if v != 10 {
+		t.Errorf("low outlier %v was not pulled to 10", v)
+	}
+
+	v, _ = matrix.GetValue(10, 0)
+	if v != 90 {
+		t.Errorf("high outlier %v was not pulled to 90", v)
+	}
+
+	for i := 1; i < 10; i++ {
+		v, _ = matrix.GetValue(i, 0)
+		if v != float64(i*10) {
+			t.Errorf("interior value %v at row %d was changed", v, i)
+		}
+		v, _ = matrix.GetValue(i, 1)
+		if v != 5 {
+			t.Errorf("constant value %v at row %d was changed", v, i)
+		}
+	}
+
+	err = matrix.Winsorize(0.9, 0.1)
+	if err != ErrInvalidArgument {
+		t.Errorf("ErrInvalidArgument was not returned for inverted quantiles")
+	}
+
+	// NaN values are not used for the bounds and are left unchanged
+	missing := NewMatrixFloat64(1)
+	missing.AddRows([][]float64{{1}, {math.NaN()}, {3}, {100}})
+	err = missing.Winsorize(0.1, 0.9)
+	if err != nil {
+		t.Errorf("winsorize error: %+v", err)
+	}
+
+	values, _ := missing.GetColumnData(0)
+	if math.Abs(values[0]-1.4) > 1e-9 || !math.IsNaN(values[1]) || values[2] != 3 || math.Abs(values[3]-80.6) > 1e-9 {
+		t.Errorf("winsorized column %v is not [1.4 NaN 3 80.6]", values)
+	}
+
+	// an infinite outlier does not stop the lower bound being found
+	infinite := NewMatrixFloat64(1)
+	infinite.AddRows([][]float64{{1}, {2}, {math.Inf(1)}})
+	err = infinite.Winsorize(0.5, 1)
+	if err != nil {
+		t.Errorf("winsorize error: %+v", err)
+	}
+
+	values, _ = infinite.GetColumnData(0)
+	if values[0] != 2 || values[1] != 2 || !math.IsInf(values[2], 1) {
+		t.Errorf("winsorized column %v is not [2 2 +Inf]", values)
+	}
+}
+
+func TestMatrixFloat64ConstantColumns(t *testing.T) {
+	matrix := NewMatrixFloat64(4)
+	matrix.AddRows([][]float64{
+		{1, 7, 3, math.NaN()},
+		{2, 7, 3, math.NaN()},
+		{3, 7, 4, math.NaN()},
+	})
+
+	constant := matrix.ConstantColumns()
+	if len(constant) != 2 || constant[0] != 1 || constant[1] != 3 {
+		t.Errorf("constant columns %v are not [1 3]", constant)
+	}
+
+	if len(NewMatrixFloat64(2).ConstantColumns()) != 0 {
+		t.Errorf("constant columns of an empty matrix are not empty")
+	}
+}
+
+func TestMatrixFloat64ColumnStats(t *testing.T) {
+	matrix := Random(50, 4, rand.New(rand.NewSource(1)))
+	matrix.AddRow([]float64{-1, -2, -3, -4})
+
+	mins, maxes, sums := matrix.ColumnStats()
+	expectedMins, expectedMaxes := matrix.ColumnMins(), matrix.ColumnMaxes()
+	for j := 0; j < 4; j++ {
+		column, _ := matrix.GetColumnData(j)
+		if mins[j] != expectedMins[j] {
+			t.Errorf("min %v of column %d is not %v", mins[j], j, expectedMins[j])
+		}
+		if maxes[j] != expectedMaxes[j] {
+			t.Errorf("max %v of column %d is not %v", maxes[j], j, expectedMaxes[j])
+		}
+		if math.Abs(sums[j]-column.Sum()) > 1e-9 {
+			t.Errorf("sum %v of column %d is not %v", sums[j], j, column.Sum())
+		}
+	}
+
+	mins, maxes, sums = NewMatrixFloat64(2).ColumnStats()
+	if len(mins) != 0 || len(maxes) != 0 || len(sums) != 0 {
+		t.Errorf("column stats of an empty matrix are not empty")
+	}
+}
+
+func BenchmarkMatrixFloat64ColumnStats(b *testing.B) {
+	matrix := Random(1000, 1000, rand.New(rand.NewSource(1)))
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		matrix.ColumnStats()
+	}
+}
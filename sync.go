@@ -0,0 +1,84 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+import (
+	"sync"
+)
+
+// SyncMatrixFloat64 wraps a MatrixFloat64 so that it can be
+// safely read and updated from multiple goroutines.
+type SyncMatrixFloat64 struct {
+	mu     sync.RWMutex
+	matrix *MatrixFloat64
+}
+
+// NewSyncMatrixFloat64 creates a SyncMatrixFloat64 that guards
+// the provided matrix. The matrix should not be used directly
+// once it has been wrapped.
+func NewSyncMatrixFloat64(matrix *MatrixFloat64) *SyncMatrixFloat64 {
+	return &SyncMatrixFloat64{
+		matrix: matrix,
+	}
+}
+
+// AddRow will append the float64 array to the matrix as a new row.
+// If the size of the row does not match the number of columns
+// in the matrix then an ErrRowSize will be returned.
+func (s *SyncMatrixFloat64) AddRow(row []float64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.matrix.AddRow(row)
+}
+
+// Columns will return the number of columns found
+// in the matrix.
+func (s *SyncMatrixFloat64) Columns() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.matrix.Columns()
+}
+
+// GetValue will return the float64 value found at the row and column
+// arguments provided. It will return an error if something is
+// invalid about either the row or column argument.
+func (s *SyncMatrixFloat64) GetValue(row, column int) (float64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.matrix.GetValue(row, column)
+}
+
+// Rows will return the number of rows found
+// in the matrix.
+func (s *SyncMatrixFloat64) Rows() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.matrix.Rows()
+}
+
+// UpdateValue will update the value found at the provided row and column
+// arguments.
+// If the row or column are out of bounds for the matrix then the proper
+// error will be returned.
+func (s *SyncMatrixFloat64) UpdateValue(value float64, row, column int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.matrix.UpdateValue(value, row, column)
+}
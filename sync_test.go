@@ -0,0 +1,56 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSyncMatrixFloat64(t *testing.T) {
+	matrix := NewSyncMatrixFloat64(NewMatrixFloat64(2))
+	matrix.AddRow([]float64{0, 0})
+
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(2)
+
+		// writer
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				matrix.AddRow([]float64{float64(w), float64(i)})
+				matrix.UpdateValue(float64(i), 0, w%2)
+			}
+		}(w)
+
+		// reader
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				rows := matrix.Rows()
+				_, err := matrix.GetValue(rows-1, matrix.Columns()-1)
+				if err != nil {
+					t.Errorf("get value error: %+v", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if matrix.Rows() != 401 {
+		t.Errorf("rows %d is not 401", matrix.Rows())
+	}
+}